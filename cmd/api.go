@@ -0,0 +1,444 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+var (
+	apiRawFields []string
+	apiFields    []string
+	apiParams    []string
+	apiHeaders   []string
+	apiInput     string
+	apiPaginate  bool
+	apiInclude   bool
+)
+
+var apiMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// Send arbitrary authenticated requests to the Vapi API
+var apiCmd = &cobra.Command{
+	Use:   "api <METHOD> <path>",
+	Short: "Make an authenticated request to the Vapi API",
+	Long: `Make an authenticated HTTP request to any Vapi API endpoint and print the response.
+
+Requests use the API key and environment of the active account, so endpoints
+the CLI doesn't wrap yet (squads, files, analytics, logs, ...) are one command away.
+
+Request bodies are built from fields or read from a file:
+  -f key=value    String field. Dots create nested objects: -f model.provider=openai
+  -F key=value    Typed field. Numbers, true/false, null and JSON literals are
+                  decoded; @file reads the value from a file
+  --input file    Send the file (or - for stdin) as the request body
+
+The response is printed as JSON and works with --output, --query and --template.`,
+	Example: `  vapi api GET /squad
+  vapi api GET /call -q limit=5 -q assistantId=asst_123
+  vapi api GET /call --paginate --query '.[*].id'
+  vapi api POST /squad -f name=Support -F members='[{"assistantId":"asst_123"}]'
+  vapi api PATCH /assistant/asst_123 --input patch.json
+  vapi api GET /file --include -H 'X-Trace: cli'`,
+	Args: cobra.ExactArgs(2),
+	RunE: analytics.TrackCommandWrapper("api", "request", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		method := strings.ToUpper(args[0])
+		if !containsString(apiMethods, method) {
+			return fmt.Errorf("unsupported method %q (use one of %s)", args[0], strings.Join(apiMethods, ", "))
+		}
+
+		path, query, err := splitPathQuery(args[1])
+		if err != nil {
+			return err
+		}
+		for _, param := range apiParams {
+			key, value, err := splitKeyValue(param)
+			if err != nil {
+				return fmt.Errorf("invalid query parameter: %w", err)
+			}
+			query.Add(key, value)
+		}
+
+		header, err := parseHeaders(apiHeaders)
+		if err != nil {
+			return err
+		}
+
+		body, err := buildRequestBody(apiRawFields, apiFields, apiInput)
+		if err != nil {
+			return err
+		}
+		if body != nil && (method == http.MethodGet || method == http.MethodHead) {
+			return fmt.Errorf("%s requests cannot have a body; use -q for query parameters", method)
+		}
+		if apiPaginate && method != http.MethodGet {
+			return fmt.Errorf("--paginate only works with GET requests")
+		}
+
+		req := &client.RawRequest{Method: method, Path: path, Query: query, Header: header, Body: body}
+		if apiPaginate {
+			result, err := paginateRaw(cmd, req)
+			if err != nil {
+				return err
+			}
+			return output.Render(result, nil)
+		}
+
+		resp, err := vapiClient.DoRaw(ctx, req)
+		if err != nil {
+			return err
+		}
+		if apiInclude {
+			writeResponseHeaders(os.Stdout, resp)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(resp.Body)))
+		}
+		return renderRawBody(resp.Body)
+	}),
+}
+
+// paginateRaw follows a list endpoint to the end and returns every item.
+// Plain arrays are walked with createdAt cursors like the list commands;
+// {results, metadata} responses are walked page by page.
+func paginateRaw(cmd *cobra.Command, req *client.RawRequest) (interface{}, error) {
+	ctx := cmd.Context()
+
+	pageSize := client.DefaultPageSize
+	if limit := req.Query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+		pageSize = n
+	} else {
+		req.Query.Set("limit", strconv.Itoa(pageSize))
+	}
+
+	fetch := func(query url.Values) (interface{}, error) {
+		page := *req
+		page.Query = query
+		resp, err := vapiClient.DoRaw(ctx, &page)
+		if err != nil {
+			return nil, err
+		}
+		if apiInclude {
+			writeResponseHeaders(os.Stdout, resp)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(resp.Body)))
+		}
+		return decodeJSON(resp.Body)
+	}
+
+	first, err := fetch(req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := first.(type) {
+	case []interface{}:
+		pending := v
+		it := client.NewIterator(client.ListOptions{PageSize: pageSize}, func(_ context.Context, page client.PageRequest) ([]interface{}, error) {
+			if pending != nil {
+				items := pending
+				pending = nil
+				return items, nil
+			}
+			query := cloneValues(req.Query)
+			setTimeParam(query, "createdAtGt", page.CreatedAtGt)
+			setTimeParam(query, "createdAtLt", page.CreatedAtLt)
+			setTimeParam(query, "createdAtLe", page.CreatedAtLe)
+			next, err := fetch(query)
+			if err != nil {
+				return nil, err
+			}
+			items, ok := next.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected a JSON array while paginating")
+			}
+			return items, nil
+		})
+		all := []interface{}{}
+		for it.Next(ctx) {
+			all = append(all, it.Item())
+		}
+		return all, it.Err()
+	case map[string]interface{}:
+		results, ok := v["results"].([]interface{})
+		metadata, hasMeta := v["metadata"].(map[string]interface{})
+		if !ok || !hasMeta {
+			return v, nil
+		}
+		total, _ := jsonInt(metadata["totalItems"])
+		current, _ := jsonInt(metadata["currentPage"])
+		if current == 0 {
+			current = 1
+		}
+		all := results
+		for len(results) > 0 && len(all) < total {
+			current++
+			query := cloneValues(req.Query)
+			query.Set("page", strconv.Itoa(current))
+			next, err := fetch(query)
+			if err != nil {
+				return nil, err
+			}
+			page, _ := next.(map[string]interface{})
+			results, _ = page["results"].([]interface{})
+			all = append(all, results...)
+		}
+		return all, nil
+	default:
+		return first, nil
+	}
+}
+
+// buildRequestBody assembles a JSON body from -f/-F fields or --input
+func buildRequestBody(rawFields, typedFields []string, input string) ([]byte, error) {
+	if input != "" {
+		if len(rawFields) > 0 || len(typedFields) > 0 {
+			return nil, fmt.Errorf("--input cannot be combined with -f or -F")
+		}
+		if input == "-" {
+			return io.ReadAll(os.Stdin)
+		}
+		data, err := os.ReadFile(filepath.Clean(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input file: %w", err)
+		}
+		return data, nil
+	}
+
+	if len(rawFields) == 0 && len(typedFields) == 0 {
+		return nil, nil
+	}
+
+	body := map[string]interface{}{}
+	for _, field := range rawFields {
+		key, value, err := splitKeyValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field: %w", err)
+		}
+		if err := setFieldPath(body, key, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range typedFields {
+		key, raw, err := splitKeyValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field: %w", err)
+		}
+		value, err := parseTypedValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		if err := setFieldPath(body, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(body)
+}
+
+// parseTypedValue decodes JSON literals and reads @file references. Anything
+// that isn't valid JSON is kept as a string.
+func parseTypedValue(raw string) (interface{}, error) {
+	if strings.HasPrefix(raw, "@") {
+		data, err := os.ReadFile(filepath.Clean(raw[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", raw[1:], err)
+		}
+		raw = strings.TrimRight(string(data), "\n")
+		if v, err := decodeJSON([]byte(raw)); err == nil {
+			return v, nil
+		}
+		return raw, nil
+	}
+	if v, err := decodeJSON([]byte(raw)); err == nil {
+		return v, nil
+	}
+	return raw, nil
+}
+
+// setFieldPath sets a dotted key such as "model.provider" in obj, creating
+// intermediate objects as needed
+func setFieldPath(obj map[string]interface{}, path string, value interface{}) error {
+	parts := strings.Split(path, ".")
+	current := obj
+	for i, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid field name %q", path)
+		}
+		if i == len(parts)-1 {
+			current[part] = value
+			return nil
+		}
+		next, exists := current[part]
+		if !exists || next == nil {
+			child := map[string]interface{}{}
+			current[part] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not an object", path, strings.Join(parts[:i+1], "."))
+		}
+		current = child
+	}
+	return nil
+}
+
+func splitKeyValue(s string) (string, string, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("%q must be in key=value form", s)
+	}
+	return key, value, nil
+}
+
+func splitPathQuery(raw string) (string, url.Values, error) {
+	path, rawQuery, _ := strings.Cut(raw, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid query string in path: %w", err)
+	}
+	return path, query, nil
+}
+
+func parseHeaders(values []string) (http.Header, error) {
+	header := http.Header{}
+	for _, h := range values {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header %q must be in 'Name: value' form", h)
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+func writeResponseHeaders(w io.Writer, resp *client.RawResponse) {
+	fmt.Fprintf(w, "%s %s\n", resp.Proto, resp.Status)
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			fmt.Fprintf(w, "%s: %s\n", name, value)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// renderRawBody prints JSON responses through the output layer and anything
+// else verbatim
+func renderRawBody(body []byte) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	v, err := decodeJSON(body)
+	if err != nil {
+		_, err = os.Stdout.Write(body)
+		return err
+	}
+	return output.Render(v, nil)
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func jsonInt(v interface{}) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return int(i), err == nil
+}
+
+func cloneValues(values url.Values) url.Values {
+	out := make(url.Values, len(values))
+	for k, v := range values {
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
+
+func setTimeParam(query url.Values, key string, t *time.Time) {
+	if t != nil {
+		query.Set(key, t.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+
+	apiCmd.Flags().StringArrayVarP(&apiRawFields, "raw-field", "f", nil, "Add a string field to the request body (key=value, repeatable)")
+	apiCmd.Flags().StringArrayVarP(&apiFields, "field", "F", nil, "Add a typed field to the request body (key=value or key=@file, repeatable)")
+	apiCmd.Flags().StringArrayVarP(&apiParams, "param", "q", nil, "Add a URL query parameter (key=value, repeatable)")
+	apiCmd.Flags().StringArrayVarP(&apiHeaders, "header", "H", nil, "Add a request header ('Name: value', repeatable)")
+	apiCmd.Flags().StringVar(&apiInput, "input", "", "File to send as the request body, or - for stdin")
+	apiCmd.Flags().BoolVar(&apiPaginate, "paginate", false, "Follow pagination and print every item as one JSON array")
+	apiCmd.Flags().BoolVarP(&apiInclude, "include", "i", false, "Print the HTTP status line and response headers")
+}
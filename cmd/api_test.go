@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRequestBody(t *testing.T) {
+	dir := t.TempDir()
+	promptFile := filepath.Join(dir, "prompt.txt")
+	require.NoError(t, os.WriteFile(promptFile, []byte("You are helpful.\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		raw      []string
+		typed    []string
+		expected string
+		hasError bool
+	}{
+		{name: "no fields", expected: ""},
+		{name: "string fields stay strings", raw: []string{"name=Support", "number=14155551234"}, expected: `{"name":"Support","number":"14155551234"}`},
+		{name: "typed fields are decoded", typed: []string{"maxTokens=250", "enabled=true", "voice=null", "tags=[\"a\"]"}, expected: `{"enabled":true,"maxTokens":250,"tags":["a"],"voice":null}`},
+		{name: "typed non-json is a string", typed: []string{"name=Support"}, expected: `{"name":"Support"}`},
+		{name: "dotted keys nest", raw: []string{"model.provider=openai", "model.model=gpt-4o"}, expected: `{"model":{"model":"gpt-4o","provider":"openai"}}`},
+		{name: "file reference", typed: []string{"prompt=@" + promptFile}, expected: `{"prompt":"You are helpful."}`},
+		{name: "missing equals", raw: []string{"name"}, hasError: true},
+		{name: "nesting under a scalar", raw: []string{"model=openai", "model.provider=openai"}, hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := buildRequestBody(tt.raw, tt.typed, "")
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, body)
+				return
+			}
+			assert.JSONEq(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestBuildRequestBodyInput(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(input, []byte(`{"name":"Support"}`), 0o600))
+
+	body, err := buildRequestBody(nil, nil, input)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Support"}`, string(body))
+
+	_, err = buildRequestBody([]string{"name=x"}, nil, input)
+	assert.Error(t, err)
+}
+
+func TestSplitPathQuery(t *testing.T) {
+	path, query, err := splitPathQuery("/call?limit=5&assistantId=a1")
+	require.NoError(t, err)
+	assert.Equal(t, "/call", path)
+	assert.Equal(t, "5", query.Get("limit"))
+	assert.Equal(t, "a1", query.Get("assistantId"))
+}
+
+func TestParseHeaders(t *testing.T) {
+	header, err := parseHeaders([]string{"X-Trace: cli", "Accept:text/plain"})
+	require.NoError(t, err)
+	assert.Equal(t, "cli", header.Get("X-Trace"))
+	assert.Equal(t, "text/plain", header.Get("Accept"))
+
+	_, err = parseHeaders([]string{"no-colon"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/apply"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+var (
+	applyFiles  []string
+	applyDryRun bool
+	applyPrune  bool
+	applyYes    bool
+)
+
+// Reconcile the account with manifests kept in version control
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file|dir>",
+	Short: "Create or update resources from YAML/JSON manifests",
+	Long: `Apply declarative manifests for tools, assistants, workflows and phone numbers.
+
+Each manifest has a kind, a stable name and a spec holding the API payload:
+
+  kind: Assistant
+  name: support
+  spec:
+    firstMessage: Hi, how can I help?
+    model:
+      provider: openai
+      model: gpt-4o
+      toolIds: [ref:Tool/lookup-order]
+
+Manifests are matched to remote resources by name, and a field-level plan of
+creates, updates and deletes is printed before anything changes. Changes are
+applied in dependency order (Tool → Assistant → Workflow → PhoneNumber), so a
+"ref:Kind/name" value can point at a resource created in the same run.
+
+Only fields present in a manifest are compared; fields that exist only on the
+remote resource are left alone.
+
+A Tool's name is stored in its function block, so every Tool spec needs one,
+even an empty "function: {}" for tools such as endCall or transferCall.`,
+	Example: `  vapi apply -f vapi/
+  vapi apply -f vapi/ --dry-run
+  vapi apply -f tools.yaml -f assistants/ --prune
+  vapi apply -f vapi/ --dry-run -o json`,
+	Args: cobra.NoArgs,
+	RunE: analytics.TrackCommandWrapper("apply", "apply", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if len(applyFiles) == 0 {
+			return fmt.Errorf("provide at least one manifest file or directory with -f")
+		}
+
+		manifests, err := apply.LoadManifests(applyFiles)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "📋 Planning %d manifest(s)...\n", len(manifests))
+		plan, err := apply.BuildPlan(ctx, vapiClient, manifests, apply.Options{Prune: applyPrune})
+		if err != nil {
+			return err
+		}
+
+		if err := renderPlan(plan); err != nil {
+			return fmt.Errorf("failed to display plan: %w", err)
+		}
+
+		if !plan.HasChanges() {
+			fmt.Fprintln(os.Stderr, "\nNo changes. Remote resources match the manifests.")
+			return nil
+		}
+		if applyDryRun {
+			fmt.Fprintln(os.Stderr, "\nDry run: no changes were applied.")
+			return nil
+		}
+
+		// Deleting resources needs an explicit yes
+		if deletes := plan.Count(apply.ActionDelete); deletes > 0 && !applyYes {
+			var confirm bool
+			prompt := &survey.Confirm{
+				Message: fmt.Sprintf("Apply this plan, deleting %d resource(s)?", deletes),
+				Default: false,
+			}
+			if err := survey.AskOne(prompt, &confirm); err != nil {
+				return fmt.Errorf("apply canceled: %w", err)
+			}
+			if !confirm {
+				fmt.Fprintln(os.Stderr, "Apply canceled.")
+				return nil
+			}
+		}
+
+		fmt.Fprintln(os.Stderr)
+		if err := apply.Apply(ctx, vapiClient, plan, os.Stderr); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "\nApplied: %d created, %d updated, %d deleted\n",
+			plan.Count(apply.ActionCreate), plan.Count(apply.ActionUpdate), plan.Count(apply.ActionDelete))
+		analytics.TrackEvent("apply_success", map[string]interface{}{
+			"created": plan.Count(apply.ActionCreate),
+			"updated": plan.Count(apply.ActionUpdate),
+			"deleted": plan.Count(apply.ActionDelete),
+		})
+		return nil
+	}),
+}
+
+// renderPlan prints the plan as a diff for people, or through the output
+// layer when a structured format or --query/--template is selected
+func renderPlan(plan *apply.Plan) error {
+	if !output.IsStructured() && !output.HasSelector() {
+		plan.Write(os.Stdout)
+		return nil
+	}
+
+	table := &output.Table{Headers: []string{"ACTION", "KIND", "NAME", "ID", "FIELDS"}}
+	for _, c := range plan.Changes {
+		table.Rows = append(table.Rows, []string{string(c.Action), c.Kind, c.Name, c.ID, strconv.Itoa(len(c.Fields))})
+	}
+	return output.Render(plan, table)
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringArrayVarP(&applyFiles, "filename", "f", nil, "Manifest file or directory (repeatable)")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the plan without changing anything")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete remote resources of the applied kinds that have no manifest")
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Skip the confirmation prompt before deleting resources")
+}
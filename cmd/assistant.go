@@ -19,19 +19,14 @@ Authors:
 package cmd
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
-	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
 
 	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/output"
 )
 
@@ -53,43 +48,22 @@ var listAssistantCmd = &cobra.Command{
 	Short: "List all assistants",
 	Long:  `Display all assistants in your account with their IDs, names, and metadata.`,
 	RunE: analytics.TrackCommandWrapper("assistant", "list", func(cmd *cobra.Command, args []string) error {
-		fmt.Println("📋 Listing assistants...")
+		fmt.Fprintln(os.Stderr, "📋 Listing assistants...")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Fetch up to 50 assistants from the API
-		listRequest := &vapi.AssistantsListRequest{
-			Limit: vapi.Float64(50),
-		}
-
-		assistants, err := vapiClient.GetClient().Assistants.List(ctx, listRequest)
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			// Check if this is a deserialization error related to new features
-			if strings.Contains(err.Error(), "cannot be deserialized") {
-				fmt.Println("⚠️  Warning: The Vapi API returned data in a format not yet supported by this CLI version.")
-				fmt.Println("   This usually happens when new features are added to Vapi.")
-				fmt.Println("   Please check for CLI updates: https://github.com/VapiAI/cli/releases")
-				fmt.Println()
-				fmt.Printf("   Technical details: %v\n", err)
-				return fmt.Errorf("incompatible API response format")
-			}
-			return fmt.Errorf("failed to list assistants: %w", err)
-		}
-
-		if len(assistants) == 0 {
-			fmt.Println("No assistants found. Create one with 'vapi assistant create'")
-			analytics.TrackEvent("assistant_list_empty", map[string]interface{}{
-				"count": 0,
-			})
-			return nil
+			return err
 		}
 
-		// Display in a readable table format
-		fmt.Printf("\nFound %d assistant(s):\n\n", len(assistants))
-		fmt.Printf("%-36s %-30s %-20s\n", "ID", "Name", "Created")
-		fmt.Printf("%-36s %-30s %-20s\n", "----", "----", "-------")
+		// Stream assistants page by page so long lists stay cheap
+		it := vapiClient.ListAssistants(opts)
+		stream := output.NewStream([]string{"ID", "NAME", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			assistant := it.Item()
 
-		for _, assistant := range assistants {
 			name := "Unnamed"
 			if assistant.Name != nil {
 				name = *assistant.Name
@@ -97,11 +71,43 @@ var listAssistantCmd = &cobra.Command{
 
 			created := assistant.CreatedAt.Format("2006-01-02 15:04")
 
-			fmt.Printf("%-36s %-30s %-20s\n", assistant.Id, name, created)
+			if err := stream.Add(assistant, []string{assistant.Id, name, created}); err != nil {
+				return fmt.Errorf("failed to display assistants: %w", err)
+			}
+		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawPages("/assistant", nil), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					rawField(item, "name", "Unnamed"),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list assistants: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list assistants: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display assistants: %w", err)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No assistants found. Create one with 'vapi assistant create'")
+			analytics.TrackEvent("assistant_list_empty", map[string]interface{}{
+				"count": 0,
+			})
+			return nil
 		}
 
+		fmt.Fprintf(os.Stderr, "\nFound %d assistant(s)\n", count)
+
 		analytics.TrackEvent("assistant_list_success", map[string]interface{}{
-			"count": len(assistants),
+			"count": count,
 		})
 
 		return nil
@@ -111,86 +117,59 @@ var listAssistantCmd = &cobra.Command{
 var createAssistantCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new assistant",
-	Long: `Create a new Vapi assistant interactively.
-	
-For advanced configuration (voice selection, model parameters, tools), 
-use the Vapi dashboard at https://dashboard.vapi.ai`,
+	Long: `Create a new Vapi assistant.
+
+Provide the full configuration with --file or --json (--json - reads stdin),
+set common fields with flags, or combine both: flags override the payload.
+
+The payload is checked against the API schema before it is sent; see
+'vapi validate'.
+
+Without a payload or flags, an interactive wizard lists the available model,
+voice and transcriber providers when running in a terminal. In CI, pass a
+payload or flags instead.`,
+	Example: `  vapi assistant create
+  vapi assistant create --file assistant.json
+  vapi assistant create --name Support --model-provider anthropic --model claude-sonnet-4-20250514 \
+    --voice-provider 11labs --voice-id sarah --transcriber deepgram:nova-3 --system-prompt-file prompt.md`,
+	Args: cobra.NoArgs,
 	RunE: analytics.TrackCommandWrapper("assistant", "create", func(cmd *cobra.Command, args []string) error {
-		fmt.Println("🤖 Create a new Vapi assistant")
-		fmt.Println()
-
-		// Basic assistant configuration via interactive prompts
-		var config struct {
-			Name         string
-			FirstMessage string
-		}
+		ctx := cmd.Context()
 
-		questions := []*survey.Question{
-			{
-				Name:     "Name",
-				Prompt:   &survey.Input{Message: "Assistant name:"},
-				Validate: survey.Required,
-			},
-			{
-				Name: "FirstMessage",
-				Prompt: &survey.Input{
-					Message: "First message (greeting):",
-					Default: "Hello! How can I help you today?",
-				},
-			},
-		}
-
-		if err := survey.Ask(questions, &config); err != nil {
-			return fmt.Errorf("assistant creation canceled: %w", err)
-		}
-
-		fmt.Println()
-		fmt.Println("ℹ️  Note: This creates a basic assistant with default settings.")
-		fmt.Println("   For advanced configuration (model, voice, prompts), use the Vapi dashboard.")
-		fmt.Println()
-
-		// Confirm before creation
-		var confirmCreate bool
-		confirmPrompt := &survey.Confirm{
-			Message: "Create assistant with these settings?",
-			Default: true,
-		}
-
-		if err := survey.AskOne(confirmPrompt, &confirmCreate); err != nil || !confirmCreate {
-			fmt.Println("Creation canceled.")
-			analytics.TrackEvent("assistant_create_canceled", nil)
-			return nil
+		payload, err := assistantPayloadFromFlags(cmd)
+		if err != nil {
+			return err
 		}
-
-		fmt.Println("\n🔄 Creating assistant...")
-
-		ctx := context.Background()
-
-		// Create the assistant via API
-		createRequest := &vapi.CreateAssistantDto{
-			Name:         &config.Name,
-			FirstMessage: &config.FirstMessage,
-			Voice: &vapi.CreateAssistantDtoVoice{
-				VapiVoice: &vapi.VapiVoice{
-					VoiceId: vapi.VapiVoiceVoiceIdElliot,
-				},
-			},
+		switch {
+		case payload != nil:
+			if err := validatePayload(cmd, client.Assistants, false, payload, payloadSource(cmd), payloadSourceName(cmd)); err != nil {
+				return err
+			}
+		case !stdinIsTerminal():
+			return fmt.Errorf("provide --file, --json or flags such as --name when not running in a terminal")
+		default:
+			if payload, err = runAssistantWizard(); err != nil || payload == nil {
+				return err
+			}
 		}
 
-		assistant, err := vapiClient.GetClient().Assistants.Create(ctx, createRequest)
+		fmt.Fprintln(os.Stderr, "🔄 Creating assistant...")
+		assistant, err := vapiClient.CreateRaw(ctx, client.Assistants, payload)
 		if err != nil {
 			return fmt.Errorf("failed to create assistant: %w", err)
 		}
 
-		fmt.Println("✅ Assistant created successfully!")
-		fmt.Printf("ID: %s\n", assistant.Id)
-		fmt.Printf("Name: %s\n", config.Name)
-		fmt.Printf("First Message: %s\n", config.FirstMessage)
-		fmt.Println("\nYour assistant is now available in the dashboard for advanced configuration:")
-		fmt.Printf("https://dashboard.vapi.ai/assistants/%s\n", assistant.Id)
+		assistantID, _ := assistant["id"].(string)
+		fmt.Fprintln(os.Stderr, "✅ Assistant created successfully!")
+		fmt.Fprintf(os.Stderr, "ID: %s\n", assistantID)
+		fmt.Fprintf(os.Stderr, "Dashboard: %s/assistants/%s\n", vapiClient.GetConfig().GetDashboardURL(), assistantID)
+
+		if err := output.Render(assistant, nil); err != nil {
+			return fmt.Errorf("failed to display assistant: %w", err)
+		}
 
 		analytics.TrackEvent("assistant_create_success", map[string]interface{}{
-			"assistant_id": assistant.Id,
+			"assistant_id": assistantID,
 		})
 
 		return nil
@@ -198,24 +177,30 @@ use the Vapi dashboard at https://dashboard.vapi.ai`,
 }
 
 var getAssistantCmd = &cobra.Command{
-	Use:   "get [assistant-id]",
+	Use:   "get [assistant-id|name]",
 	Short: "Get details of a specific assistant",
 	Long:  `Retrieve the full configuration of an assistant including voice, model, and tool settings.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: analytics.TrackCommandWrapper("assistant", "get", func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		assistantID := args[0]
+		ctx := cmd.Context()
+		assistantID, err := vapiClient.Resolve(ctx, client.Assistants, args[0])
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("🔍 Getting assistant details for ID: %s\n", assistantID)
+		fmt.Fprintf(os.Stderr, "🔍 Getting assistant details for ID: %s\n", assistantID)
 
 		// Fetch the assistant configuration
 		assistant, err := vapiClient.GetClient().Assistants.Get(ctx, assistantID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/assistant/"+assistantID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get assistant: %w", err)
 		}
 
-		// Display as formatted JSON for easy reading
-		if err := output.PrintJSON(assistant); err != nil {
+		// Display in the selected format (JSON by default)
+		if err := output.Render(assistant, nil); err != nil {
 			return fmt.Errorf("failed to display assistant: %w", err)
 		}
 
@@ -224,7 +209,7 @@ var getAssistantCmd = &cobra.Command{
 }
 
 var updateAssistantCmd = &cobra.Command{
-	Use:   "update [assistant-id]",
+	Use:   "update [assistant-id|name]",
 	Short: "Update an existing assistant",
 	Long: `Update an assistant's configuration.
 
@@ -233,64 +218,25 @@ Examples:
   vapi assistant update <id> --file assistant.json
   cat assistant.json | vapi assistant update <id> --json -
   vapi assistant update <id> --json '{"name":"New Name"}'
+  vapi assistant update <id> --set model.temperature=0.3 --dry-run
 
+The previous configuration is saved locally first; see 'vapi assistant history'.
+To change an assistant interactively as YAML, use 'vapi assistant edit <id>'.
 Complex updates can also be done via the Vapi dashboard at https://dashboard.vapi.ai`,
 	Args: cobra.ExactArgs(1),
 	RunE: analytics.TrackCommandWrapper("assistant", "update", func(cmd *cobra.Command, args []string) error {
-		assistantID := args[0]
-
-		// Flags
-		jsonStr, _ := cmd.Flags().GetString("json")
-		filePath, _ := cmd.Flags().GetString("file")
-
-		if jsonStr == "" && filePath == "" {
-			return fmt.Errorf("provide --json or --file for update payload")
-		}
-
-		var payloadBytes []byte
-
-		if filePath != "" {
-			filePath = filepath.Clean(filePath)
-			b, err := os.ReadFile(filePath) // #nosec G304 - filePath is user-provided and intentional
-			if err != nil {
-				return fmt.Errorf("failed to read --file: %w", err)
-			}
-			payloadBytes = b
-		} else {
-			// jsonStr provided
-			if jsonStr == "-" {
-				// read from stdin
-				b, err := io.ReadAll(os.Stdin)
-				if err != nil {
-					return fmt.Errorf("failed to read stdin: %w", err)
-				}
-				payloadBytes = b
-			} else {
-				payloadBytes = []byte(jsonStr)
-			}
+		respBody, err := updateFromPayload(cmd, client.Assistants, args[0])
+		if err != nil || respBody == nil {
+			return err
 		}
 
-		// Basic validation
-		var tmp map[string]interface{}
-		if err := json.Unmarshal(payloadBytes, &tmp); err != nil {
-			return fmt.Errorf("invalid JSON: %w", err)
-		}
-
-		ctx := context.Background()
-
-		// Use low-level raw request helper
-		respBody, err := vapiClient.DoRawJSON(ctx, "PATCH", fmt.Sprintf("/assistants/%s", assistantID), payloadBytes)
-		if err != nil {
-			return fmt.Errorf("failed to update assistant: %w", err)
-		}
-
-		fmt.Println("✅ Assistant updated successfully")
+		fmt.Fprintln(os.Stderr, "✅ Assistant updated successfully")
 		if name, ok := respBody["name"].(string); ok && name != "" {
-			fmt.Printf("Name: %s\n", name)
+			fmt.Fprintf(os.Stderr, "Name: %s\n", name)
 		}
 
-		// Print the updated assistant JSON
-		if err := output.PrintJSON(respBody); err != nil {
+		// Print the updated assistant in the selected format
+		if err := output.Render(respBody, nil); err != nil {
 			return fmt.Errorf("failed to display response: %w", err)
 		}
 
@@ -300,13 +246,24 @@ Complex updates can also be done via the Vapi dashboard at https://dashboard.vap
 
 // nolint:dupl // Delete commands follow a similar pattern across resources
 var deleteAssistantCmd = &cobra.Command{
-	Use:   "delete [assistant-id]",
+	Use:   "delete [assistant-id|name]",
 	Short: "Delete an assistant",
-	Long:  `Permanently delete an assistant. This cannot be undone.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Permanently delete an assistant. This cannot be undone.
+
+Deletion is refused while phone numbers, squads or other resources still
+reference the assistant. Use --force to delete it anyway.`,
+	Args: cobra.ExactArgs(1),
 	RunE: analytics.TrackCommandWrapper("assistant", "delete", func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		assistantID := args[0]
+		ctx := cmd.Context()
+		assistantID, err := vapiClient.Resolve(ctx, client.Assistants, args[0])
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkDependents(ctx, "assistant", assistantID, force); err != nil {
+			return err
+		}
 
 		// Require explicit confirmation for destructive actions
 		var confirmDelete bool
@@ -320,20 +277,21 @@ var deleteAssistantCmd = &cobra.Command{
 		}
 
 		if !confirmDelete {
-			fmt.Println("Deletion canceled.")
+			fmt.Fprintln(os.Stderr, "Deletion canceled.")
 			analytics.TrackEvent("assistant_delete_canceled", nil)
 			return nil
 		}
 
-		fmt.Printf("🗑️  Deleting assistant with ID: %s\n", assistantID)
+		fmt.Fprintf(os.Stderr, "🗑️  Deleting assistant with ID: %s\n", assistantID)
 
 		// Execute deletion via API
-		_, err := vapiClient.GetClient().Assistants.Delete(ctx, assistantID)
+		_, err = vapiClient.GetClient().Assistants.Delete(ctx, assistantID)
 		if err != nil {
 			return fmt.Errorf("failed to delete assistant: %w", err)
 		}
+		vapiClient.ForgetNames(client.Assistants)
 
-		fmt.Println("✅ Assistant deleted successfully")
+		fmt.Fprintln(os.Stderr, "✅ Assistant deleted successfully")
 		return nil
 	}),
 }
@@ -345,8 +303,14 @@ func init() {
 	assistantCmd.AddCommand(getAssistantCmd)
 	assistantCmd.AddCommand(updateAssistantCmd)
 	assistantCmd.AddCommand(deleteAssistantCmd)
+	assistantCmd.AddCommand(newHistoryCmd("assistant", client.Assistants))
+	assistantCmd.AddCommand(newRollbackCmd("assistant", client.Assistants))
+
+	addListFlags(listAssistantCmd)
+
+	addAssistantCreateFlags(createAssistantCmd)
+
+	addUpdateFlags(updateAssistantCmd, "assistant")
 
-	// Flags for update
-	updateAssistantCmd.Flags().String("json", "", "Raw JSON payload string or '-' to read from stdin")
-	updateAssistantCmd.Flags().String("file", "", "Path to JSON file with assistant payload")
+	deleteAssistantCmd.Flags().Bool("force", false, "Delete even if phone numbers, squads or other resources still reference it")
 }
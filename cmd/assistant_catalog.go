@@ -0,0 +1,176 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	vapi "github.com/VapiAI/server-sdk-go"
+)
+
+// providerOption is a provider offered by the assistant wizard and the
+// choices it accepts. Choices come from the SDK's enums; an empty list
+// means the provider takes free-form IDs.
+type providerOption struct {
+	Provider string
+	Choices  []string
+	Default  string
+}
+
+// modelProviders are the LLM providers offered by the wizard
+var modelProviders = []providerOption{
+	{
+		Provider: "openai",
+		Default:  string(vapi.OpenAiModelModelGpt4O),
+		Choices: enumStrings(
+			vapi.OpenAiModelModelGpt41, vapi.OpenAiModelModelGpt41Mini, vapi.OpenAiModelModelGpt41Nano,
+			vapi.OpenAiModelModelGpt4O, vapi.OpenAiModelModelGpt4OMini, vapi.OpenAiModelModelChatgpt4OLatest,
+			vapi.OpenAiModelModelO3, vapi.OpenAiModelModelO3Mini, vapi.OpenAiModelModelO4Mini,
+			vapi.OpenAiModelModelGpt4Turbo, vapi.OpenAiModelModelGpt35Turbo,
+		),
+	},
+	{
+		Provider: "anthropic",
+		Default:  string(vapi.AnthropicModelModelClaudeSonnet420250514),
+		Choices: enumStrings(
+			vapi.AnthropicModelModelClaudeSonnet420250514, vapi.AnthropicModelModelClaudeOpus420250514,
+			vapi.AnthropicModelModelClaude37Sonnet20250219, vapi.AnthropicModelModelClaude35Sonnet20241022,
+			vapi.AnthropicModelModelClaude35Haiku20241022, vapi.AnthropicModelModelClaude3Haiku20240307,
+		),
+	},
+	{
+		Provider: "google",
+		Default:  string(vapi.GoogleModelModelGemini25Flash),
+		Choices: enumStrings(
+			vapi.GoogleModelModelGemini25Pro, vapi.GoogleModelModelGemini25Flash, vapi.GoogleModelModelGemini25FlashLite,
+			vapi.GoogleModelModelGemini20Flash, vapi.GoogleModelModelGemini20FlashLite,
+			vapi.GoogleModelModelGemini15Pro, vapi.GoogleModelModelGemini15Flash,
+		),
+	},
+	{
+		Provider: "groq",
+		Default:  string(vapi.GroqModelModelLlama3370BVersatile),
+		Choices: enumStrings(
+			vapi.GroqModelModelLlama3370BVersatile, vapi.GroqModelModelLlama318BInstant,
+			vapi.GroqModelModelMetaLlamaLlama4Maverick17B128EInstruct, vapi.GroqModelModelMetaLlamaLlama4Scout17B16EInstruct,
+			vapi.GroqModelModelDeepseekR1DistillLlama70B, vapi.GroqModelModelGemma29BIt,
+		),
+	},
+}
+
+// voiceProviders are the TTS providers offered by the wizard
+var voiceProviders = []providerOption{
+	{
+		Provider: "vapi",
+		Default:  string(vapi.VapiVoiceVoiceIdElliot),
+		Choices: enumStrings(
+			vapi.VapiVoiceVoiceIdElliot, vapi.VapiVoiceVoiceIdKylie, vapi.VapiVoiceVoiceIdRohan,
+			vapi.VapiVoiceVoiceIdLily, vapi.VapiVoiceVoiceIdSavannah, vapi.VapiVoiceVoiceIdHana,
+			vapi.VapiVoiceVoiceIdNeha, vapi.VapiVoiceVoiceIdCole, vapi.VapiVoiceVoiceIdHarry,
+			vapi.VapiVoiceVoiceIdPaige, vapi.VapiVoiceVoiceIdSpencer,
+		),
+	},
+	{
+		Provider: "openai",
+		Default:  string(vapi.OpenAiVoiceIdEnumAlloy),
+		Choices: enumStrings(
+			vapi.OpenAiVoiceIdEnumAlloy, vapi.OpenAiVoiceIdEnumEcho, vapi.OpenAiVoiceIdEnumFable,
+			vapi.OpenAiVoiceIdEnumOnyx, vapi.OpenAiVoiceIdEnumNova, vapi.OpenAiVoiceIdEnumShimmer,
+		),
+	},
+	{
+		Provider: "deepgram",
+		Default:  string(vapi.DeepgramVoiceIdAsteria),
+		Choices: enumStrings(
+			vapi.DeepgramVoiceIdAsteria, vapi.DeepgramVoiceIdLuna, vapi.DeepgramVoiceIdStella,
+			vapi.DeepgramVoiceIdAthena, vapi.DeepgramVoiceIdHera, vapi.DeepgramVoiceIdOrion,
+			vapi.DeepgramVoiceIdArcas, vapi.DeepgramVoiceIdPerseus, vapi.DeepgramVoiceIdAngus,
+			vapi.DeepgramVoiceIdOrpheus, vapi.DeepgramVoiceIdHelios, vapi.DeepgramVoiceIdZeus,
+		),
+	},
+	{
+		Provider: "11labs",
+		Default:  string(vapi.ElevenLabsVoiceIdEnumSarah),
+		Choices: enumStrings(
+			vapi.ElevenLabsVoiceIdEnumBurt, vapi.ElevenLabsVoiceIdEnumMarissa, vapi.ElevenLabsVoiceIdEnumAndrea,
+			vapi.ElevenLabsVoiceIdEnumSarah, vapi.ElevenLabsVoiceIdEnumPhillip, vapi.ElevenLabsVoiceIdEnumSteve,
+			vapi.ElevenLabsVoiceIdEnumJoseph, vapi.ElevenLabsVoiceIdEnumMyra, vapi.ElevenLabsVoiceIdEnumPaula,
+			vapi.ElevenLabsVoiceIdEnumRyan, vapi.ElevenLabsVoiceIdEnumDrew, vapi.ElevenLabsVoiceIdEnumPaul,
+			vapi.ElevenLabsVoiceIdEnumMatilda, vapi.ElevenLabsVoiceIdEnumMark,
+		),
+	},
+	{Provider: "cartesia"},
+}
+
+// transcriberProviders are the speech-to-text providers offered by the
+// wizard
+var transcriberProviders = []providerOption{
+	{
+		Provider: "deepgram",
+		Default:  string(vapi.DeepgramTranscriberModelNova3),
+		Choices: enumStrings(
+			vapi.DeepgramTranscriberModelNova3, vapi.DeepgramTranscriberModelNova3General,
+			vapi.DeepgramTranscriberModelNova3Medical, vapi.DeepgramTranscriberModelNova2,
+			vapi.DeepgramTranscriberModelNova2Phonecall, vapi.DeepgramTranscriberModelNova2Conversationalai,
+		),
+	},
+	{
+		Provider: "openai",
+		Default:  string(vapi.OpenAiTranscriberModelGpt4OTranscribe),
+		Choices:  enumStrings(vapi.OpenAiTranscriberModelGpt4OTranscribe, vapi.OpenAiTranscriberModelGpt4OMiniTranscribe),
+	},
+	{
+		Provider: "gladia",
+		Default:  string(vapi.GladiaTranscriberModelFast),
+		Choices:  enumStrings(vapi.GladiaTranscriberModelFast, vapi.GladiaTranscriberModelAccurate, vapi.GladiaTranscriberModelSolaria1),
+	},
+	{
+		Provider: "google",
+		Default:  string(vapi.GoogleTranscriberModelGemini20Flash),
+		Choices: enumStrings(
+			vapi.GoogleTranscriberModelGemini25Flash, vapi.GoogleTranscriberModelGemini20Flash,
+			vapi.GoogleTranscriberModelGemini20FlashLite, vapi.GoogleTranscriberModelGemini15Flash,
+		),
+	},
+	{Provider: "assembly-ai"},
+	{Provider: "speechmatics"},
+}
+
+func enumStrings[T ~string](values ...T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+func providerNames(options []providerOption) []string {
+	names := make([]string, len(options))
+	for i, o := range options {
+		names[i] = o.Provider
+	}
+	return names
+}
+
+func findProvider(options []providerOption, provider string) (providerOption, bool) {
+	for _, o := range options {
+		if o.Provider == provider {
+			return o, true
+		}
+	}
+	return providerOption{}, false
+}
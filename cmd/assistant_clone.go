@@ -0,0 +1,230 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/config"
+	"github.com/VapiAI/cli/pkg/output"
+	"github.com/VapiAI/cli/pkg/patch"
+	"github.com/VapiAI/cli/pkg/snapshot"
+)
+
+var (
+	cloneName       string
+	cloneAccount    string
+	cloneSets       []string
+	cloneUnsets     []string
+	cloneCloneTools bool
+)
+
+// cloneResult is what 'assistant clone' prints, so the new ID can be
+// picked up by scripts with --query id
+type cloneResult struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	SourceID string            `json:"sourceId"`
+	Account  string            `json:"account,omitempty"`
+	Tools    map[string]string `json:"tools,omitempty"` // Source tool ID → tool ID used by the clone
+}
+
+// Duplicate an assistant to try a variation without touching the original
+var cloneAssistantCmd = &cobra.Command{
+	Use:   "clone [assistant-id|name]",
+	Short: "Copy an assistant within this account or into another one",
+	Long: `Create a copy of an assistant's full configuration.
+
+Server-managed fields are dropped and the copy is named "<name> (copy)"
+unless --name is given. Use --set to change fields of the copy with the same
+dotted paths as the update commands, e.g. --set model.temperature=0.2.
+
+With --to-account the copy is created in another configured account and
+keeps the original name. Tools referenced by model.toolIds are matched to
+tools with the same name in that account. Tools missing there are an error
+unless --clone-tools copies them too; a tool that uses a credential of the
+source account is never copied. Other IDs that only exist in the source
+account, such as credentialIds, model.knowledgeBaseId, transfer destination
+assistants and server credentials, are refused: point them at the target's
+resources with --set or drop them with --unset.
+
+The new assistant is printed in the selected output format.`,
+	Example: `  vapi assistant clone support --name "Support (short prompt)" --set model.temperature=0.2
+  vapi assistant clone support --to-account production --clone-tools
+  vapi assistant clone support --to-account production --unset credentialIds
+  NEW_ID=$(vapi assistant clone support --query id)`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("assistant", "clone", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		source, err := fetchAssistant(ctx, vapiClient, args[0])
+		if err != nil {
+			return err
+		}
+		sourceID, _ := source["id"].(string)
+
+		target := vapiClient
+		account := ""
+		if cfg := config.GetConfig(); cfg != nil {
+			account = cfg.ActiveAccount
+		}
+		if cloneAccount != "" {
+			if target, err = client.NewVapiClientForAccount(cloneAccount); err != nil {
+				return err
+			}
+			account = cloneAccount
+		}
+
+		name := cloneName
+		if name == "" {
+			name = cloneDefaultName(source, sourceID, cloneAccount != "")
+		}
+		payload, err := clonePayload(source, name, cloneSets, cloneUnsets)
+		if err != nil {
+			return err
+		}
+
+		var tools map[string]string
+		if cloneAccount != "" {
+			if refs := snapshot.AccountScopedRefs(payload, nil); len(refs) > 0 {
+				return fmt.Errorf("the assistant refers to resources that only exist in the source account: %s\nPoint them at the target account's resources with --set, or remove them with --unset", strings.Join(refs, ", "))
+			}
+			toolIDs := assistantToolIDs(payload)
+			tools, err = snapshot.MapTools(ctx, vapiClient, target, toolIDs, cloneCloneTools, os.Stderr)
+			var missing *snapshot.MissingToolsError
+			if errors.As(err, &missing) {
+				return fmt.Errorf("%w; pass --clone-tools to copy them", err)
+			}
+			if err != nil {
+				return err
+			}
+			setAssistantToolIDs(payload, toolIDs, tools)
+		}
+
+		// The copy is sent as raw JSON rather than through the SDK's create
+		// DTO, which would drop any field this SDK version doesn't model yet
+		fmt.Fprintln(os.Stderr, "🔄 Cloning assistant...")
+		created, err := target.CreateRaw(ctx, client.Assistants, payload)
+		if err != nil {
+			return fmt.Errorf("failed to create clone: %w", err)
+		}
+
+		result := cloneResult{SourceID: sourceID, Account: account, Tools: tools}
+		result.ID, _ = created["id"].(string)
+		result.Name, _ = created["name"].(string)
+		fmt.Fprintf(os.Stderr, "✅ Cloned assistant %s → %s\n", sourceID, result.ID)
+		fmt.Fprintf(os.Stderr, "Dashboard: %s/assistants/%s\n", target.GetConfig().GetDashboardURL(), result.ID)
+
+		if err := output.Render(result, nil); err != nil {
+			return fmt.Errorf("failed to display assistant: %w", err)
+		}
+
+		analytics.TrackEvent("assistant_clone_success", map[string]interface{}{
+			"cross_account": cloneAccount != "",
+			"overrides":     len(cloneSets),
+			"tools":         len(tools),
+		})
+		return nil
+	}),
+}
+
+// cloneDefaultName names a copy "<name> (copy)", or keeps the name when the
+// copy goes to another account. An unnamed source is named after its ID.
+func cloneDefaultName(source map[string]interface{}, sourceID string, crossAccount bool) string {
+	name := client.Assistants.NameOf(source)
+	if crossAccount {
+		return name
+	}
+	if name == "" {
+		short, _, _ := strings.Cut(sourceID, "-")
+		return "Copy of " + short
+	}
+	return name + " (copy)"
+}
+
+// clonePayload turns a fetched assistant into a create payload named name,
+// with each path=value override in sets applied and each path in unsets
+// removed
+func clonePayload(source map[string]interface{}, name string, sets, unsets []string) (map[string]interface{}, error) {
+	payload := client.Assistants.Editable(source)
+	if name != "" {
+		payload["name"] = name
+	}
+	ops := make([]patch.Op, 0, len(sets)+len(unsets))
+	for _, set := range sets {
+		path, raw, err := splitKeyValue(set)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --set: %w", err)
+		}
+		ops = append(ops, patch.Op{Kind: patch.Set, Path: path, Value: patch.ParseValue(raw)})
+	}
+	for _, path := range unsets {
+		ops = append(ops, patch.Op{Kind: patch.Unset, Path: path})
+	}
+	return patch.Apply(payload, ops)
+}
+
+// assistantToolIDs returns the IDs in an assistant's model.toolIds
+func assistantToolIDs(assistant map[string]interface{}) []string {
+	model, _ := assistant["model"].(map[string]interface{})
+	list, _ := model["toolIds"].([]interface{})
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		if id, ok := item.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// setAssistantToolIDs replaces model.toolIds with the mapped IDs
+func setAssistantToolIDs(assistant map[string]interface{}, ids []string, mapping map[string]string) {
+	model, ok := assistant["model"].(map[string]interface{})
+	if !ok || len(ids) == 0 {
+		return
+	}
+	// Copy the model block so the fetched assistant is left untouched
+	updated := make(map[string]interface{}, len(model))
+	for k, v := range model {
+		updated[k] = v
+	}
+	mapped := make([]interface{}, len(ids))
+	for i, id := range ids {
+		mapped[i] = mapping[id]
+	}
+	updated["toolIds"] = mapped
+	assistant["model"] = updated
+}
+
+func init() {
+	assistantCmd.AddCommand(cloneAssistantCmd)
+
+	cloneAssistantCmd.Flags().StringVar(&cloneName, "name", "", "Name of the copy (default \"<name> (copy)\", or the same name in another account)")
+	cloneAssistantCmd.Flags().StringVar(&cloneAccount, "to-account", "", "Create the copy in another configured account")
+	cloneAssistantCmd.Flags().StringArrayVar(&cloneSets, "set", nil, "Override a field of the copy (path=value, repeatable)")
+	cloneAssistantCmd.Flags().StringArrayVar(&cloneUnsets, "unset", nil, "Remove a field from the copy (path, repeatable)")
+	cloneAssistantCmd.Flags().BoolVar(&cloneCloneTools, "clone-tools", false, "Copy referenced tools missing from the target account")
+}
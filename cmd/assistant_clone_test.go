@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClonePayload(t *testing.T) {
+	source := map[string]interface{}{
+		"id":                   "a1",
+		"orgId":                "o1",
+		"createdAt":            "2025-01-01T00:00:00Z",
+		"isServerUrlSecretSet": true,
+		"name":                 "support",
+		"model":                map[string]interface{}{"provider": "openai", "toolIds": []interface{}{"t1", "t2"}},
+	}
+
+	payload, err := clonePayload(source, "support (copy)", []string{"model.temperature=0.2", "firstMessage=Hi there"}, []string{"model.provider"})
+	require.NoError(t, err)
+	assert.NotContains(t, payload, "id")
+	assert.NotContains(t, payload, "orgId")
+	assert.NotContains(t, payload, "isServerUrlSecretSet")
+	assert.Equal(t, "support (copy)", payload["name"])
+	assert.Equal(t, "Hi there", payload["firstMessage"])
+	assert.Equal(t, 0.2, payload["model"].(map[string]interface{})["temperature"])
+	assert.NotContains(t, payload["model"], "provider")
+
+	ids := assistantToolIDs(payload)
+	assert.Equal(t, []string{"t1", "t2"}, ids)
+	setAssistantToolIDs(payload, ids, map[string]string{"t1": "n1", "t2": "n2"})
+	assert.Equal(t, []interface{}{"n1", "n2"}, payload["model"].(map[string]interface{})["toolIds"])
+
+	_, err = clonePayload(source, "", []string{"name"}, nil)
+	assert.ErrorContains(t, err, "key=value")
+}
+
+func TestCloneDefaultName(t *testing.T) {
+	named := map[string]interface{}{"name": "support"}
+	assert.Equal(t, "support (copy)", cloneDefaultName(named, "a1", false))
+	assert.Equal(t, "support", cloneDefaultName(named, "a1", true))
+	assert.Equal(t, "Copy of 5f1c2b7e", cloneDefaultName(map[string]interface{}{}, "5f1c2b7e-0000-4000-8000-000000000000", false))
+}
@@ -0,0 +1,289 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/diff"
+)
+
+// addAssistantCreateFlags registers the payload and field flags read by
+// assistantPayloadFromFlags
+func addAssistantCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("json", "", "Raw JSON payload string or '-' to read from stdin")
+	cmd.Flags().String("file", "", "Path to JSON or YAML file with assistant payload")
+	cmd.Flags().String("name", "", "Assistant name")
+	cmd.Flags().String("first-message", "", "First message the assistant says")
+	cmd.Flags().String("model-provider", "", "LLM provider, e.g. openai, anthropic, google, groq")
+	cmd.Flags().String("model", "", "LLM model, e.g. gpt-4o")
+	cmd.Flags().String("system-prompt-file", "", "File with the system prompt")
+	cmd.Flags().String("voice-provider", "", "Voice provider, e.g. vapi, 11labs, openai, deepgram")
+	cmd.Flags().String("voice-id", "", "Voice ID for the voice provider")
+	cmd.Flags().String("transcriber", "", "Transcriber as provider or provider:model, e.g. deepgram:nova-3")
+	cmd.Flags().Bool("no-validate", false, "Send the payload without checking it against the local schema")
+}
+
+// assistantPayloadFromFlags builds a create payload from --file/--json and
+// the field flags. It returns nil when none of them were given.
+func assistantPayloadFromFlags(cmd *cobra.Command) (map[string]interface{}, error) {
+	flags := cmd.Flags()
+	jsonStr, _ := flags.GetString("json")
+	filePath, _ := flags.GetString("file")
+
+	var payload map[string]interface{}
+	switch {
+	case jsonStr != "" && filePath != "":
+		return nil, fmt.Errorf("use either --json or --file, not both")
+	case filePath != "":
+		doc, err := readDocument(filePath)
+		if err != nil {
+			return nil, err
+		}
+		payload = doc
+	case jsonStr != "":
+		data, err := readJSONPayload(jsonStr, "")
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	fieldFlags := []string{"name", "first-message", "model-provider", "model", "system-prompt-file", "voice-provider", "voice-id", "transcriber"}
+	changed := false
+	for _, name := range fieldFlags {
+		changed = changed || flags.Changed(name)
+	}
+	if payload == nil && !changed {
+		return nil, nil
+	}
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+
+	if name, _ := flags.GetString("name"); name != "" {
+		payload["name"] = name
+	}
+	if msg, _ := flags.GetString("first-message"); msg != "" {
+		payload["firstMessage"] = msg
+	}
+
+	provider, _ := flags.GetString("model-provider")
+	model, _ := flags.GetString("model")
+	promptFile, _ := flags.GetString("system-prompt-file")
+	var prompt string
+	if promptFile != "" {
+		data, err := os.ReadFile(filepath.Clean(promptFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --system-prompt-file: %w", err)
+		}
+		prompt = string(data)
+	}
+	setModel(payload, provider, model, prompt)
+
+	voiceProvider, _ := flags.GetString("voice-provider")
+	voiceID, _ := flags.GetString("voice-id")
+	setProviderBlock(payload, "voice", "voiceId", voiceProvider, voiceID, "vapi")
+
+	if transcriber, _ := flags.GetString("transcriber"); transcriber != "" {
+		provider, model, _ := strings.Cut(transcriber, ":")
+		setProviderBlock(payload, "transcriber", "model", provider, model, "deepgram")
+	}
+
+	return payload, nil
+}
+
+// setModel merges the model flags into payload. The system prompt replaces
+// any existing system message.
+func setModel(payload map[string]interface{}, provider, model, prompt string) {
+	if provider == "" && model == "" && prompt == "" {
+		return
+	}
+	setProviderBlock(payload, "model", "model", provider, model, "openai")
+	if prompt == "" {
+		return
+	}
+
+	block := payload["model"].(map[string]interface{})
+	messages := []interface{}{map[string]interface{}{"role": "system", "content": prompt}}
+	if existing, ok := block["messages"].([]interface{}); ok {
+		for _, m := range existing {
+			if msg, ok := m.(map[string]interface{}); ok && msg["role"] == "system" {
+				continue
+			}
+			messages = append(messages, m)
+		}
+	}
+	block["messages"] = messages
+}
+
+// setProviderBlock sets provider and the value field of a nested block such
+// as voice, keeping the block's other settings. defaultProvider is used when
+// only the value is given and the payload doesn't name a provider.
+func setProviderBlock(payload map[string]interface{}, key, field, provider, value, defaultProvider string) {
+	if provider == "" && value == "" {
+		return
+	}
+	block, ok := payload[key].(map[string]interface{})
+	if !ok {
+		block = make(map[string]interface{})
+		payload[key] = block
+	}
+	if provider != "" {
+		block["provider"] = provider
+	} else if _, ok := block["provider"]; !ok {
+		block["provider"] = defaultProvider
+	}
+	if value != "" {
+		block[field] = value
+	}
+}
+
+// runAssistantWizard asks for a full assistant configuration. It returns
+// nil if the user declines to create it.
+func runAssistantWizard() (map[string]interface{}, error) {
+	fmt.Println("🤖 Create a new Vapi assistant")
+	fmt.Println()
+
+	var answers struct {
+		Name         string
+		FirstMessage string
+		Prompt       string
+	}
+	questions := []*survey.Question{
+		{
+			Name:     "Name",
+			Prompt:   &survey.Input{Message: "Assistant name:"},
+			Validate: survey.Required,
+		},
+		{
+			Name: "FirstMessage",
+			Prompt: &survey.Input{
+				Message: "First message (greeting):",
+				Default: "Hello! How can I help you today?",
+			},
+		},
+		{
+			Name: "Prompt",
+			Prompt: &survey.Multiline{
+				Message: "System prompt (finish with an empty line):",
+				Default: "You are a helpful voice assistant. Keep your answers short and conversational.",
+			},
+		},
+	}
+	if err := survey.Ask(questions, &answers); err != nil {
+		return nil, fmt.Errorf("assistant creation canceled: %w", err)
+	}
+
+	modelProvider, model, err := askProvider("Model provider:", "Model:", modelProviders)
+	if err != nil {
+		return nil, err
+	}
+	voiceProvider, voiceID, err := askProvider("Voice provider:", "Voice:", voiceProviders)
+	if err != nil {
+		return nil, err
+	}
+	transcriberProvider, transcriberModel, err := askProvider("Transcriber provider:", "Transcriber model:", transcriberProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"name":         answers.Name,
+		"firstMessage": answers.FirstMessage,
+	}
+	setModel(payload, modelProvider, model, answers.Prompt)
+	setProviderBlock(payload, "voice", "voiceId", voiceProvider, voiceID, "vapi")
+	setProviderBlock(payload, "transcriber", "model", transcriberProvider, transcriberModel, "deepgram")
+
+	summary, err := diff.Document(payload)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println()
+	fmt.Println(strings.Join(summary, "\n"))
+	fmt.Println()
+
+	var confirm bool
+	prompt := &survey.Confirm{Message: "Create assistant with these settings?", Default: true}
+	if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+		fmt.Println("Creation canceled.")
+		return nil, nil
+	}
+	return payload, nil
+}
+
+// askProvider asks for a provider and then one of its choices, or a
+// free-form ID for providers without a known list
+func askProvider(providerMessage, choiceMessage string, options []providerOption) (string, string, error) {
+	var provider string
+	if err := survey.AskOne(&survey.Select{
+		Message: providerMessage,
+		Options: providerNames(options),
+	}, &provider); err != nil {
+		return "", "", fmt.Errorf("assistant creation canceled: %w", err)
+	}
+
+	option, _ := findProvider(options, provider)
+	var choice string
+	var prompt survey.Prompt = &survey.Input{Message: choiceMessage, Default: option.Default}
+	if len(option.Choices) > 0 {
+		prompt = &survey.Select{Message: choiceMessage, Options: option.Choices, Default: option.Default}
+	}
+	if err := survey.AskOne(prompt, &choice); err != nil {
+		return "", "", fmt.Errorf("assistant creation canceled: %w", err)
+	}
+	return provider, choice, nil
+}
+
+// readJSONPayload returns the payload given with --json (or stdin for "-")
+// or --file
+func readJSONPayload(jsonStr, filePath string) ([]byte, error) {
+	if filePath != "" {
+		b, err := os.ReadFile(filepath.Clean(filePath)) // #nosec G304 - filePath is user-provided and intentional
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --file: %w", err)
+		}
+		return b, nil
+	}
+	if jsonStr == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return b, nil
+	}
+	return []byte(jsonStr), nil
+}
+
+// stdinIsTerminal reports whether prompts can be shown to a user
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCreateFlags(t *testing.T, args ...string) (map[string]interface{}, error) {
+	t.Helper()
+	cmd := &cobra.Command{}
+	addAssistantCreateFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return assistantPayloadFromFlags(cmd)
+}
+
+func TestAssistantPayloadFromFlags(t *testing.T) {
+	payload, err := parseCreateFlags(t)
+	require.NoError(t, err)
+	assert.Nil(t, payload, "no flags means the wizard runs")
+
+	dir := t.TempDir()
+	promptFile := filepath.Join(dir, "prompt.md")
+	require.NoError(t, os.WriteFile(promptFile, []byte("Be brief.\n"), 0o600))
+
+	payload, err = parseCreateFlags(t,
+		"--json", `{"name":"Base","model":{"provider":"openai","model":"gpt-4o","temperature":0.2,"messages":[{"role":"system","content":"old"},{"role":"assistant","content":"hi"}]}}`,
+		"--name", "Support",
+		"--model", "gpt-4.1",
+		"--system-prompt-file", promptFile,
+		"--voice-id", "Kylie",
+		"--transcriber", "deepgram:nova-3",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name": "Support",
+		"model": map[string]interface{}{
+			"provider":    "openai",
+			"model":       "gpt-4.1",
+			"temperature": 0.2,
+			"messages": []interface{}{
+				map[string]interface{}{"role": "system", "content": "Be brief.\n"},
+				map[string]interface{}{"role": "assistant", "content": "hi"},
+			},
+		},
+		"voice":       map[string]interface{}{"provider": "vapi", "voiceId": "Kylie"},
+		"transcriber": map[string]interface{}{"provider": "deepgram", "model": "nova-3"},
+	}, payload)
+
+	_, err = parseCreateFlags(t, "--json", "{}", "--file", "a.json")
+	assert.ErrorContains(t, err, "not both")
+}
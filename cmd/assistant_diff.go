@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/diff"
+)
+
+var diffExitCode bool
+
+// Compare two assistant configurations before promoting a change
+var diffAssistantCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Show the differences between two assistant configurations",
+	Long: `Show a unified diff between two assistants. Each side can be:
+
+  <assistant-id|name>            An assistant in the active account
+  <file.json|file.yaml>          A local file, e.g. saved with 'vapi assistant get'
+  account:<account>/<id|name>    An assistant in another configured account
+
+Server-managed fields (id, orgId, createdAt, updatedAt) are ignored. Both
+sides are rendered as YAML with sorted keys, so multi-line prompts are
+compared line by line.`,
+	Example: `  vapi assistant diff support support.yaml
+  vapi assistant diff account:staging/support account:production/support
+  vapi assistant diff support support.yaml --exit-code   # Fail CI on drift`,
+	Args: cobra.ExactArgs(2),
+	RunE: analytics.TrackCommandWrapper("assistant", "diff", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		var docs [2][]string
+		for i, ref := range args {
+			assistant, err := loadAssistant(ctx, ref)
+			if err != nil {
+				return err
+			}
+			if docs[i], err = diff.Document(client.StripServerFields(assistant)); err != nil {
+				return err
+			}
+		}
+
+		if !diff.Unified(os.Stdout, args[0], args[1], docs[0], docs[1]) {
+			fmt.Fprintln(os.Stderr, "No differences.")
+			return nil
+		}
+		if diffExitCode {
+			return silentExit(cmd, 1)
+		}
+		return nil
+	}),
+}
+
+// loadAssistant reads an assistant from another account, a local file or
+// the active account, in that order of precedence
+func loadAssistant(ctx context.Context, ref string) (map[string]interface{}, error) {
+	assistant, _, err := loadAssistantWithClient(ctx, ref)
+	return assistant, err
+}
+
+// loadAssistantWithClient is loadAssistant that also returns the client for
+// the account the assistant belongs to: the other account's client for an
+// account: reference, the active account's otherwise
+func loadAssistantWithClient(ctx context.Context, ref string) (map[string]interface{}, *client.VapiClient, error) {
+	if rest, ok := strings.CutPrefix(ref, "account:"); ok {
+		account, id, found := strings.Cut(rest, "/")
+		if !found || account == "" || id == "" {
+			return nil, nil, fmt.Errorf("invalid reference %q: expected account:<account>/<id|name>", ref)
+		}
+		remote, err := client.NewVapiClientForAccount(account)
+		if err != nil {
+			return nil, nil, err
+		}
+		assistant, err := fetchAssistant(ctx, remote, id)
+		return assistant, remote, err
+	}
+
+	if _, err := os.Stat(ref); err == nil {
+		assistant, err := readDocument(ref)
+		return assistant, vapiClient, err
+	}
+	assistant, err := fetchAssistant(ctx, vapiClient, ref)
+	return assistant, vapiClient, err
+}
+
+func fetchAssistant(ctx context.Context, remote *client.VapiClient, ref string) (map[string]interface{}, error) {
+	id, err := remote.Resolve(ctx, client.Assistants, ref)
+	if err != nil {
+		return nil, err
+	}
+	assistant, err := remote.FetchRaw(ctx, client.Assistants, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assistant %s: %w", ref, err)
+	}
+	return assistant, nil
+}
+
+// readDocument reads a JSON or YAML object, round-tripped through JSON so
+// its values compare equal to API responses
+func readDocument(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var parsed interface{}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("%s must contain a single object", path)
+	}
+	return doc, nil
+}
+
+func init() {
+	assistantCmd.AddCommand(diffAssistantCmd)
+
+	diffAssistantCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with status 1 when the assistants differ")
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAssistantFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "support.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("id: a1\nname: support\nmodel:\n  temperature: 0.5\n"), 0o600))
+
+	assistant, err := loadAssistant(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":    "a1",
+		"name":  "support",
+		"model": map[string]interface{}{"temperature": 0.5},
+	}, assistant)
+
+	require.NoError(t, os.WriteFile(path, []byte("- not\n- an object\n"), 0o600))
+	_, err = loadAssistant(context.Background(), path)
+	assert.ErrorContains(t, err, "must contain a single object")
+
+	_, err = loadAssistant(context.Background(), "account:staging")
+	assert.ErrorContains(t, err, "expected account:<account>/<id|name>")
+}
@@ -0,0 +1,256 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/diff"
+)
+
+// Edit an assistant in $EDITOR, the way 'kubectl edit' works
+var editAssistantCmd = &cobra.Command{
+	Use:   "edit [assistant-id|name]",
+	Short: "Edit an assistant as YAML in your editor",
+	Long: `Open an assistant's configuration as YAML in $VISUAL or $EDITOR.
+
+Server-managed and read-only fields are left out, and multi-line strings such
+as system prompts are written as block scalars. When the editor closes, the
+change is shown as a diff and, once confirmed, only the top-level fields that
+changed are sent as a PATCH. Removing a top-level field clears it.
+
+If the YAML can't be parsed or the API rejects the update, the editor reopens
+with the error at the top of the file. Save an empty file to abort.`,
+	Example: `  vapi assistant edit support
+  EDITOR="code --wait" vapi assistant edit <assistant-id>`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("assistant", "edit", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		assistantID, err := vapiClient.Resolve(ctx, client.Assistants, args[0])
+		if err != nil {
+			return err
+		}
+
+		assistant, err := vapiClient.FetchRaw(ctx, client.Assistants, assistantID)
+		if err != nil {
+			return fmt.Errorf("failed to get assistant: %w", err)
+		}
+		original := client.Assistants.Editable(assistant)
+		originalDoc, err := diff.Document(original)
+		if err != nil {
+			return err
+		}
+
+		header := fmt.Sprintf("Editing assistant %s. Lines starting with '#' are ignored;\nsave an empty file to abort.", assistantID)
+		buffer := editHeader(header, nil) + strings.Join(originalDoc, "\n") + "\n"
+
+		for {
+			edited, err := runEditor(buffer, "vapi-assistant-*.yaml")
+			if err != nil {
+				return err
+			}
+			buffer = edited
+
+			updated, err := parseEdit(edited)
+			if errors.Is(err, errEmptyEdit) {
+				fmt.Fprintln(os.Stderr, "Edit canceled: the file is empty.")
+				return nil
+			}
+			if err != nil {
+				buffer = editHeader(header, err) + stripEditHeader(edited)
+				continue
+			}
+
+			patch := minimalPatch(original, updated)
+			if len(patch) == 0 {
+				fmt.Fprintln(os.Stderr, "Edit canceled: no changes made.")
+				return nil
+			}
+
+			updatedDoc, err := diff.Document(updated)
+			if err != nil {
+				return err
+			}
+			diff.Unified(os.Stdout, "assistant/"+assistantID, "edited", originalDoc, updatedDoc)
+
+			var confirm bool
+			prompt := &survey.Confirm{
+				Message: fmt.Sprintf("Update %d field(s) of this assistant?", len(patch)),
+				Default: true,
+			}
+			if err := survey.AskOne(prompt, &confirm); err != nil {
+				return fmt.Errorf("edit canceled: %w", err)
+			}
+			if !confirm {
+				fmt.Fprintln(os.Stderr, "Edit canceled.")
+				return nil
+			}
+
+			_, err = vapiClient.UpdateRaw(ctx, client.Assistants, assistantID, patch)
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+				fmt.Fprintf(os.Stderr, "❌ The API rejected the update; reopening the editor.\n")
+				buffer = editHeader(header, errors.New(apiErr.Message())) + stripEditHeader(edited)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to update assistant: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "✅ Assistant %s updated\n", assistantID)
+			analytics.TrackEvent("assistant_edit_success", map[string]interface{}{
+				"assistant_id": assistantID,
+				"fields":       len(patch),
+			})
+			return nil
+		}
+	}),
+}
+
+// errEmptyEdit means the user saved an empty file to abort
+var errEmptyEdit = errors.New("empty edit")
+
+// editHeader builds the comment block at the top of the edited file,
+// including the error from the previous attempt, if any
+func editHeader(header string, problem error) string {
+	var b strings.Builder
+	for _, line := range strings.Split(header, "\n") {
+		b.WriteString("# " + line + "\n")
+	}
+	if problem != nil {
+		b.WriteString("#\n# The previous edit could not be applied:\n")
+		for _, line := range strings.Split(problem.Error(), "\n") {
+			b.WriteString("#   " + line + "\n")
+		}
+	}
+	b.WriteString("#\n")
+	return b.String()
+}
+
+// stripEditHeader removes the leading comment block written by editHeader
+func stripEditHeader(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	i := 0
+	for i < len(lines) && strings.HasPrefix(lines[i], "#") {
+		i++
+	}
+	return strings.Join(lines[i:], "")
+}
+
+// parseEdit decodes the edited YAML into the same value types the API
+// returns
+func parseEdit(content string) (map[string]interface{}, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if parsed == nil {
+		return nil, errEmptyEdit
+	}
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("the file must contain a single YAML mapping")
+	}
+	return doc, nil
+}
+
+// minimalPatch returns the top-level fields of after that differ from
+// before. Fields removed in after are sent as null to clear them.
+func minimalPatch(before, after map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			patch[key] = value
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
+
+// runEditor writes content to a temporary file, opens it in the user's
+// editor and returns what was saved
+func runEditor(content, pattern string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	_, writeErr := file.WriteString(content)
+	if closeErr := file.Close(); writeErr != nil || closeErr != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", errors.Join(writeErr, closeErr))
+	}
+
+	editor := strings.Fields(editorCommand())
+	// #nosec G204 - the editor is chosen by the user through $VISUAL/$EDITOR
+	editCmd := exec.Command(editor[0], append(editor[1:], path)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", editor[0], err)
+	}
+
+	edited, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return string(edited), nil
+}
+
+// editorCommand returns $VISUAL, $EDITOR or the platform's default editor
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+func init() {
+	assistantCmd.AddCommand(editAssistantCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEditAndMinimalPatch(t *testing.T) {
+	before := map[string]interface{}{
+		"name":             "support",
+		"firstMessage":     "Hi",
+		"model":            map[string]interface{}{"provider": "openai", "temperature": 0.7},
+		"voicemailMessage": "Call back later",
+	}
+
+	after, err := parseEdit("# comment\nname: support\nfirstMessage: Hello\nmodel:\n  provider: openai\n  temperature: 0.7\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"firstMessage":     "Hello",
+		"voicemailMessage": nil,
+	}, minimalPatch(before, after))
+
+	_, err = parseEdit("# only comments\n\n")
+	assert.ErrorIs(t, err, errEmptyEdit)
+
+	_, err = parseEdit("name: [unclosed\n")
+	assert.ErrorContains(t, err, "invalid YAML")
+
+	_, err = parseEdit("- a list\n")
+	assert.ErrorContains(t, err, "single YAML mapping")
+}
+
+func TestEditHeaderRoundTrip(t *testing.T) {
+	body := "name: support\n# keep me\n"
+	withError := editHeader("Editing assistant a1.", errors.New("firstMessage must be a string\nname is too long"))
+	assert.Contains(t, withError, "#   firstMessage must be a string\n#   name is too long\n")
+	assert.Equal(t, body, stripEditHeader(withError+body))
+}
+
+func TestRunEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sed as the editor")
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("VISUAL", "sed -i.bak s/Hi/Hello/")
+
+	edited, err := runEditor("firstMessage: Hi\n", "vapi-test-*.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "firstMessage: Hello\n", edited)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/lint"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+var (
+	lintFormat   string
+	lintVars     []string
+	lintDisabled []string
+)
+
+// Catch assistant configs that are valid but wrong
+var lintAssistantCmd = &cobra.Command{
+	Use:   "lint <assistant-id|name|file>",
+	Short: "Check an assistant for common misconfigurations",
+	Long: `Run static checks against an assistant in the account, in a local JSON/YAML
+file, or in another account (account:<account>/<id|name>).
+
+Referenced tools, credentials and phone numbers are checked against the API.
+Each finding has a severity; the command exits with status 1 when any
+finding is an error.
+
+Rules:
+` + lintRuleList() + `
+Use --format sarif to upload findings to code scanning in CI.`,
+	Example: `  vapi assistant lint support
+  vapi assistant lint assistant.yaml --var order_id --var customer_tier
+  vapi assistant lint assistant.yaml --format sarif > lint.sarif
+  vapi assistant lint support --disable system-prompt`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("assistant", "lint", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		// References are checked in the account the assistant came from
+		assistant, remote, err := loadAssistantWithClient(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		target := &lint.Target{
+			Assistant:  assistant,
+			Production: remote.GetConfig().IsProduction(),
+			Variables:  make(map[string]bool, len(lintVars)),
+			Remote:     remote,
+		}
+		for _, name := range lintVars {
+			target.Variables[name] = true
+		}
+
+		findings, err := lint.Run(ctx, target, lint.Rules(), lintDisabled)
+		if err != nil {
+			return err
+		}
+
+		switch lintFormat {
+		case "sarif":
+			err = lint.WriteSARIF(os.Stdout, args[0], lint.Rules(), findings)
+		case "json":
+			err = output.PrintJSON(findings)
+		case "", "text":
+			if output.IsStructured() || output.HasSelector() {
+				err = output.Render(findings, lintTable(findings))
+			} else {
+				printFindings(findings)
+			}
+		default:
+			return fmt.Errorf("unknown --format %q (valid: text, json, sarif)", lintFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to display findings: %w", err)
+		}
+
+		errorCount := lint.Count(findings, lint.SeverityError)
+		fmt.Fprintf(os.Stderr, "\n%d error(s), %d warning(s), %d info\n",
+			errorCount, lint.Count(findings, lint.SeverityWarning), lint.Count(findings, lint.SeverityInfo))
+		if errorCount > 0 {
+			return silentExit(cmd, 1)
+		}
+		return nil
+	}),
+}
+
+func printFindings(findings []lint.Finding) {
+	if len(findings) == 0 {
+		fmt.Println("✅ No problems found")
+		return
+	}
+	icons := map[lint.Severity]string{
+		lint.SeverityError:   "❌",
+		lint.SeverityWarning: "⚠️ ",
+		lint.SeverityInfo:    "ℹ️ ",
+	}
+	for _, f := range findings {
+		fmt.Printf("%s %-7s %-20s %s: %s\n", icons[f.Severity], f.Severity, f.Rule, f.Path, f.Message)
+	}
+}
+
+func lintTable(findings []lint.Finding) *output.Table {
+	table := &output.Table{Headers: []string{"SEVERITY", "RULE", "PATH", "MESSAGE"}}
+	for _, f := range findings {
+		table.Rows = append(table.Rows, []string{string(f.Severity), f.Rule, f.Path, f.Message})
+	}
+	return table
+}
+
+func lintRuleList() string {
+	var b strings.Builder
+	for _, r := range lint.Rules() {
+		fmt.Fprintf(&b, "  %-22s %s\n", r.ID, r.Description)
+	}
+	return b.String()
+}
+
+func init() {
+	assistantCmd.AddCommand(lintAssistantCmd)
+
+	lintAssistantCmd.Flags().StringVar(&lintFormat, "format", "text", "Findings format: text, json or sarif")
+	lintAssistantCmd.Flags().StringSliceVar(&lintVars, "var", nil, "Template variable supplied at call time (repeatable)")
+	lintAssistantCmd.Flags().StringSliceVar(&lintDisabled, "disable", nil, "Rule ID to skip (repeatable)")
+}
@@ -0,0 +1,260 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	vapi "github.com/VapiAI/server-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+var (
+	callEndYes         bool
+	callUpdateName     string
+	callUpdateMetadata []string
+	callUpdateFile     string
+	callUpdateYes      bool
+)
+
+// callEndTimeout is how long 'call end' waits for the call to report that
+// it has ended
+const callEndTimeout = 30 * time.Second
+
+// Hang up a call that is still ringing or in progress
+var endCallCmd = &cobra.Command{
+	Use:   "end [call-id]",
+	Short: "End an active call",
+	Long: `Terminate a call that is queued, ringing or in progress.
+
+The call is ended through its live call control URL, which Vapi provides
+when the assistant's monitorPlan.controlEnabled is true. The command waits
+for the call to report that it has ended and prints the final call.`,
+	Example: `  vapi call end <call-id>
+  vapi call end <call-id> --yes --query endedReason`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("call", "end", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		callID := args[0]
+
+		state, err := activeCall(ctx, callID)
+		if err != nil {
+			return err
+		}
+		if state.ControlURL == "" {
+			return fmt.Errorf("call %s has no control URL; set monitorPlan.controlEnabled to true on the assistant to end its calls from the CLI", callID)
+		}
+
+		if !callEndYes {
+			ok, err := confirmCallAction(fmt.Sprintf("End call %s (%s)?", callID, state.Status))
+			if err != nil || !ok {
+				return err
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "📞 Ending call %s...\n", callID)
+		if err := vapiClient.ControlCall(ctx, state.ControlURL, map[string]interface{}{"type": "end-call"}); err != nil {
+			return fmt.Errorf("failed to end call: %w", err)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, callEndTimeout)
+		defer cancel()
+		final, err := waitForCall(waitCtx, callID)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return fmt.Errorf("call %s was asked to end but has not reported it within %s; check it with 'vapi call get %s'", callID, callEndTimeout, callID)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Call ended: %s\n", final.EndedReason)
+
+		if err := output.Render(final.Call, nil); err != nil {
+			return fmt.Errorf("failed to display call: %w", err)
+		}
+		return nil
+	}),
+}
+
+// Change the name or metadata of a call that hasn't ended yet
+var updateCallCmd = &cobra.Command{
+	Use:   "update [call-id]",
+	Short: "Update a call in progress",
+	Long: `Update a call that hasn't ended yet.
+
+--name and --metadata (key=value, repeatable) cover the common changes.
+--file sends any other fields as a JSON or YAML patch; the flags are applied
+on top of it. New metadata keys are added to the call's existing metadata
+rather than replacing it. The updated call is printed in the selected output
+format.`,
+	Example: `  vapi call update <call-id> --name "QA run 42"
+  vapi call update <call-id> --metadata ticket=QA-42 --metadata tester=ada --yes
+  vapi call update <call-id> --file patch.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("call", "update", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		callID := args[0]
+
+		body, err := callUpdateBody(callUpdateFile, callUpdateName, callUpdateMetadata)
+		if err != nil {
+			return err
+		}
+
+		state, err := activeCall(ctx, callID)
+		if err != nil {
+			return err
+		}
+		current, err := callDocument(state.Call)
+		if err != nil {
+			return err
+		}
+		keepCallMetadata(body, current)
+
+		if !callUpdateYes {
+			ok, err := confirmCallAction(fmt.Sprintf("Update %s of call %s?", strings.Join(sortedFields(body), ", "), callID))
+			if err != nil || !ok {
+				return err
+			}
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode update: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "🔄 Updating call %s...\n", callID)
+		updated, err := vapiClient.DoRawJSON(ctx, http.MethodPatch, "/call/"+callID, data)
+		if err != nil {
+			return fmt.Errorf("failed to update call: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "✅ Call updated")
+
+		if err := output.Render(updated, nil); err != nil {
+			return fmt.Errorf("failed to display call: %w", err)
+		}
+		return nil
+	}),
+}
+
+// activeCall fetches a call and fails with a clear error if it has already
+// ended
+func activeCall(ctx context.Context, callID string) (*callState, error) {
+	state, err := fetchCallState(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == string(vapi.CallStatusEnded) {
+		reason := state.EndedReason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		return nil, fmt.Errorf("call %s has already ended (%s)", callID, reason)
+	}
+	return state, nil
+}
+
+// callUpdateBody builds the PATCH body of 'call update' from --file, with
+// --name and --metadata applied on top
+func callUpdateBody(filePath, name string, metadata []string) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	if filePath != "" {
+		doc, err := readDocument(filePath)
+		if err != nil {
+			return nil, err
+		}
+		body = doc
+	}
+	if name != "" {
+		body["name"] = name
+	}
+	if err := mergeKeyValues(body, "metadata", metadata, "--metadata"); err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("nothing to update: pass --name, --metadata or --file")
+	}
+	return body, nil
+}
+
+// keepCallMetadata merges the metadata in body over the call's existing
+// metadata, so a PATCH that replaces the object whole doesn't drop keys the
+// update doesn't mention
+func keepCallMetadata(body, call map[string]interface{}) {
+	update, ok := body["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	existing, _ := call["metadata"].(map[string]interface{})
+	merged := make(map[string]interface{}, len(existing)+len(update))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range update {
+		merged[k] = v
+	}
+	body["metadata"] = merged
+}
+
+// confirmCallAction asks before changing a live call. It returns false,
+// after saying so, when the user declines.
+func confirmCallAction(message string) (bool, error) {
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("pass --yes to confirm when not running in a terminal")
+	}
+	var confirm bool
+	prompt := &survey.Confirm{Message: message, Default: false}
+	if err := survey.AskOne(prompt, &confirm); err != nil {
+		return false, fmt.Errorf("canceled: %w", err)
+	}
+	if !confirm {
+		fmt.Fprintln(os.Stderr, "Canceled.")
+	}
+	return confirm, nil
+}
+
+func sortedFields(body map[string]interface{}) []string {
+	fields := make([]string, 0, len(body))
+	for k := range body {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func init() {
+	callCmd.AddCommand(endCallCmd)
+	callCmd.AddCommand(updateCallCmd)
+
+	endCallCmd.Flags().BoolVarP(&callEndYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	updateCallCmd.Flags().StringVar(&callUpdateName, "name", "", "New name for the call")
+	updateCallCmd.Flags().StringArrayVar(&callUpdateMetadata, "metadata", nil, "Metadata to set on the call (key=value, repeatable)")
+	updateCallCmd.Flags().StringVar(&callUpdateFile, "file", "", "Path to JSON or YAML file with fields to update")
+	updateCallCmd.Flags().BoolVarP(&callUpdateYes, "yes", "y", false, "Skip the confirmation prompt")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallUpdateBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "patch.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("name: from file\nmetadata:\n  ticket: QA-1\n  tester: ada\n"), 0o600))
+
+	body, err := callUpdateBody(file, "QA run 42", []string{"ticket=QA-42"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "QA run 42",
+		"metadata": map[string]interface{}{"ticket": "QA-42", "tester": "ada"},
+	}, body)
+	assert.Equal(t, []string{"metadata", "name"}, sortedFields(body))
+
+	body, err = callUpdateBody("", "", []string{"ticket=QA-42"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"metadata": map[string]interface{}{"ticket": "QA-42"}}, body)
+
+	_, err = callUpdateBody("", "", nil)
+	assert.ErrorContains(t, err, "nothing to update")
+
+	_, err = callUpdateBody("", "", []string{"ticket"})
+	assert.ErrorContains(t, err, "invalid --metadata")
+}
+
+func TestKeepCallMetadata(t *testing.T) {
+	call := map[string]interface{}{"metadata": map[string]interface{}{"crm": "42", "ticket": "QA-1"}}
+
+	body := map[string]interface{}{"metadata": map[string]interface{}{"ticket": "QA-42"}}
+	keepCallMetadata(body, call)
+	assert.Equal(t, map[string]interface{}{"crm": "42", "ticket": "QA-42"}, body["metadata"])
+
+	// Updates without metadata are left alone
+	body = map[string]interface{}{"name": "QA run"}
+	keepCallMetadata(body, call)
+	assert.Equal(t, map[string]interface{}{"name": "QA run"}, body)
+
+	body = map[string]interface{}{"metadata": map[string]interface{}{"ticket": "QA-42"}}
+	keepCallMetadata(body, map[string]interface{}{})
+	assert.Equal(t, map[string]interface{}{"ticket": "QA-42"}, body["metadata"])
+}
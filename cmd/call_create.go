@@ -0,0 +1,435 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	vapi "github.com/VapiAI/server-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+	"github.com/VapiAI/cli/pkg/schema"
+)
+
+var (
+	callAssistant     string
+	callWorkflow      string
+	callSquad         string
+	callPhoneNumber   string
+	callTo            string
+	callCustomerName  string
+	callVariables     []string
+	callMetadata      []string
+	callOverridesFile string
+	callWait          bool
+)
+
+// Exit codes of 'call create --wait', by how the call ended
+const (
+	callExitCompleted   = 0 // Connected and ended normally
+	callExitNotAnswered = 2 // Busy, no answer, voicemail or canceled
+	callExitFailed      = 3 // Ended by an error
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// notAnsweredReasons are ended reasons where the call never reached a person
+var notAnsweredReasons = map[string]bool{
+	"customer-busy":                      true,
+	"customer-did-not-answer":            true,
+	"voicemail":                          true,
+	"manually-canceled":                  true,
+	"scheduled-call-deleted":             true,
+	"twilio-reported-customer-misdialed": true,
+	"vonage-rejected":                    true,
+	"call.forwarding.operator-busy":      true,
+	"call.ringing.sip-inbound-caller-hungup-before-call-connect": true,
+}
+
+// completedReasons are ended reasons of a call that connected and finished
+// the way calls normally do
+var completedReasons = map[string]bool{
+	"customer-ended-call":                       true,
+	"assistant-ended-call":                      true,
+	"assistant-ended-call-after-message-spoken": true,
+	"assistant-ended-call-with-hangup-task":     true,
+	"assistant-said-end-call-phrase":            true,
+	"assistant-forwarded-call":                  true,
+	"exceeded-max-duration":                     true,
+	"silence-timed-out":                         true,
+	"vonage-completed":                          true,
+	"call.in-progress.sip-completed-call":       true,
+	"call.in-progress.twilio-completed-call":    true,
+	"call.ringing.hook-executed-say":            true,
+	"call.ringing.hook-executed-transfer":       true,
+}
+
+// callRequest holds the resolved inputs of 'call create'
+type callRequest struct {
+	AssistantID   string
+	WorkflowID    string
+	SquadID       string
+	PhoneNumberID string
+	To            string
+	CustomerName  string
+	Variables     []string               // key=value template variables
+	Metadata      []string               // key=value metadata entries
+	Overrides     map[string]interface{} // From --overrides-file
+}
+
+// Place an outbound phone call from a Vapi number
+var createCallCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Place an outbound call",
+	Long: `Call a phone number with an assistant, workflow or squad.
+
+Exactly one of --assistant, --workflow or --squad picks who runs the call;
+each takes an ID or a name. --phone-number is the Vapi number to call from
+and --to is the customer's number in E.164 form, e.g. +14155550123.
+
+--variable fills the {{templates}} in the assistant's prompts and messages
+and --metadata is attached to the call's assistant overrides. Both are
+key=value and can be repeated. --overrides-file (JSON or YAML) supplies any
+other assistantOverrides, or workflowOverrides with --workflow; the flags
+are applied on top of it. Squad calls take no overrides: set them on the
+squad's members instead.
+
+With --wait the command follows the call until it ends, prints the final
+call and exits with a status based on its ended reason:
+
+  0  the call connected and ended normally
+  2  the call was not answered (busy, no answer, voicemail, canceled)
+  3  the call ended with an error`,
+	Example: `  vapi call create --assistant support --phone-number "Main line" --to +14155550123
+  vapi call create --assistant support --phone-number <id> --to +14155550123 \
+    --customer-name "Ada" --variable plan=pro --metadata ticket=QA-42 --wait
+  vapi call create --workflow onboarding --phone-number <id> --to +14155550123 --query id`,
+	Args: cobra.NoArgs,
+	RunE: analytics.TrackCommandWrapper("call", "create", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		req, err := resolveCallRequest(cmd)
+		if err != nil {
+			return err
+		}
+		body, err := buildCallRequest(req)
+		if err != nil {
+			return err
+		}
+
+		noValidate, _ := cmd.Flags().GetBool("no-validate")
+		fmt.Fprintf(os.Stderr, "📞 Calling %s...\n", req.To)
+		call, callID, err := placeCall(ctx, body, noValidate)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "✅ Call created: %s\n", callID)
+
+		analytics.TrackEvent("call_create_success", map[string]interface{}{
+			"target":    callTargetKind(req),
+			"variables": len(req.Variables),
+			"wait":      callWait,
+		})
+
+		if !callWait {
+			if err := output.Render(call, nil); err != nil {
+				return fmt.Errorf("failed to display call: %w", err)
+			}
+			return nil
+		}
+
+		state, err := waitForCall(ctx, callID)
+		if err != nil {
+			return err
+		}
+		if err := output.Render(state.Call, nil); err != nil {
+			return fmt.Errorf("failed to display call: %w", err)
+		}
+		code := endedReasonExitCode(state.EndedReason)
+		switch code {
+		case callExitCompleted:
+			fmt.Fprintf(os.Stderr, "✅ Call ended: %s\n", state.EndedReason)
+			return nil
+		case callExitNotAnswered:
+			fmt.Fprintf(os.Stderr, "📵 Call not answered: %s\n", state.EndedReason)
+		default:
+			fmt.Fprintf(os.Stderr, "❌ Call failed: %s\n", state.EndedReason)
+		}
+		return silentExit(cmd, code)
+	}),
+}
+
+// resolveCallRequest validates the flags of 'call create' and turns names
+// into IDs
+func resolveCallRequest(cmd *cobra.Command) (callRequest, error) {
+	ctx := cmd.Context()
+	req := callRequest{
+		To:           strings.TrimSpace(callTo),
+		CustomerName: callCustomerName,
+		Variables:    callVariables,
+		Metadata:     callMetadata,
+	}
+
+	targets := 0
+	for _, ref := range []string{callAssistant, callWorkflow, callSquad} {
+		if ref != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return req, fmt.Errorf("specify exactly one of --assistant, --workflow or --squad")
+	}
+	if callPhoneNumber == "" {
+		return req, fmt.Errorf("--phone-number is required: the Vapi number to call from")
+	}
+	if !e164Pattern.MatchString(req.To) {
+		return req, fmt.Errorf("--to must be a phone number in E.164 form such as +14155550123, got %q", callTo)
+	}
+
+	var err error
+	switch {
+	case callAssistant != "":
+		req.AssistantID, err = vapiClient.Resolve(ctx, client.Assistants, callAssistant)
+	case callWorkflow != "":
+		req.WorkflowID, err = vapiClient.Resolve(ctx, client.Workflows, callWorkflow)
+	default:
+		req.SquadID, err = vapiClient.Resolve(ctx, client.Squads, callSquad)
+	}
+	if err != nil {
+		return req, err
+	}
+	if req.PhoneNumberID, err = vapiClient.Resolve(ctx, client.PhoneNumbers, callPhoneNumber); err != nil {
+		return req, err
+	}
+
+	if callOverridesFile != "" {
+		if req.Overrides, err = readDocument(callOverridesFile); err != nil {
+			return req, err
+		}
+		// Squad calls refuse overrides in buildCallRequest
+		if req.SquadID == "" {
+			if err := validateOverrides(cmd, req); err != nil {
+				return req, err
+			}
+		}
+	}
+	return req, nil
+}
+
+// validateOverrides checks --overrides-file against the SDK's overrides type
+// for the call's target, unless --no-validate is set
+func validateOverrides(cmd *cobra.Command, req callRequest) error {
+	if skip, _ := cmd.Flags().GetBool("no-validate"); skip {
+		return nil
+	}
+	errs := schema.For(overridesType(req)).Validate(req.Overrides)
+	if len(errs) == 0 {
+		return nil
+	}
+	if source, err := os.ReadFile(filepath.Clean(callOverridesFile)); err == nil {
+		schema.Locate(errs, source)
+	}
+	verr := &schema.ValidationError{Source: callOverridesFile, Errors: errs}
+	return fmt.Errorf("%w\nFix the overrides, or pass --no-validate to send them anyway", verr)
+}
+
+// buildCallRequest assembles the API request body for a resolved 'call
+// create'. Overrides are passed through as given, so keys the SDK's
+// overrides types don't know are kept.
+func buildCallRequest(req callRequest) (map[string]interface{}, error) {
+	customer := map[string]interface{}{"number": req.To}
+	if req.CustomerName != "" {
+		customer["name"] = req.CustomerName
+	}
+	body := map[string]interface{}{
+		"phoneNumberId": req.PhoneNumberID,
+		"customer":      customer,
+	}
+	switch {
+	case req.AssistantID != "":
+		body["assistantId"] = req.AssistantID
+	case req.WorkflowID != "":
+		body["workflowId"] = req.WorkflowID
+	case req.SquadID != "":
+		body["squadId"] = req.SquadID
+	}
+
+	if req.WorkflowID != "" && len(req.Metadata) > 0 {
+		return nil, fmt.Errorf("--metadata is not supported for workflow calls")
+	}
+	// Call-level overrides only apply to assistant calls; a squad's members
+	// carry their own assistantOverrides
+	if req.SquadID != "" && (len(req.Variables) > 0 || len(req.Metadata) > 0 || req.Overrides != nil) {
+		return nil, fmt.Errorf("--variable, --metadata and --overrides-file are not supported for squad calls; set assistantOverrides on the squad's members instead")
+	}
+	overrides := make(map[string]interface{}, len(req.Overrides)+2)
+	for k, v := range req.Overrides {
+		overrides[k] = v
+	}
+	if err := mergeKeyValues(overrides, "variableValues", req.Variables, "--variable"); err != nil {
+		return nil, err
+	}
+	if err := mergeKeyValues(overrides, "metadata", req.Metadata, "--metadata"); err != nil {
+		return nil, err
+	}
+	if len(overrides) == 0 {
+		return body, nil
+	}
+
+	if req.WorkflowID != "" {
+		body["workflowOverrides"] = overrides
+	} else {
+		body["assistantOverrides"] = overrides
+	}
+	return body, nil
+}
+
+// placeCall creates the call through the SDK and returns it with its ID.
+// With raw set (--no-validate) the body is sent as JSON instead, because
+// the SDK's overrides types would drop keys they don't model.
+func placeCall(ctx context.Context, body map[string]interface{}, raw bool) (interface{}, string, error) {
+	if raw {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode call: %w", err)
+		}
+		call, err := vapiClient.DoRawJSON(ctx, http.MethodPost, "/call", data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create call: %w", err)
+		}
+		callID, _ := call["id"].(string)
+		if callID == "" {
+			return nil, "", fmt.Errorf("the API did not return a call")
+		}
+		return call, callID, nil
+	}
+
+	dto, err := callDTO(body)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := vapiClient.GetClient().Calls.Create(ctx, dto)
+	if client.IsDecodeError(err) {
+		// The call was placed; only the response is beyond this SDK
+		return nil, "", fmt.Errorf("the call was placed but its details could not be read (%s); find it with 'vapi call list'", extractErrorSummary(err.Error()))
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create call: %w", err)
+	}
+	call := resp.GetCall()
+	if call == nil {
+		return nil, "", fmt.Errorf("the API did not return a call")
+	}
+	return call, call.Id, nil
+}
+
+// callDTO decodes a request body into the SDK's create type. Validated
+// overrides already match the SDK's types, so nothing is lost.
+func callDTO(body map[string]interface{}) (*vapi.CreateCallDto, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call: %w", err)
+	}
+	var dto vapi.CreateCallDto
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to encode call: %w", err)
+	}
+	return &dto, nil
+}
+
+// mergeKeyValues adds each key=value in pairs to the object at field of
+// overrides, keeping entries from the overrides file that aren't repeated
+func mergeKeyValues(overrides map[string]interface{}, field string, pairs []string, flag string) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	merged := map[string]interface{}{}
+	if existing, ok := overrides[field].(map[string]interface{}); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for _, pair := range pairs {
+		key, value, err := splitKeyValue(pair)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", flag, err)
+		}
+		merged[key] = value
+	}
+	overrides[field] = merged
+	return nil
+}
+
+func overridesType(req callRequest) reflect.Type {
+	if req.WorkflowID != "" {
+		return reflect.TypeOf(vapi.WorkflowOverrides{})
+	}
+	return reflect.TypeOf(vapi.AssistantOverrides{})
+}
+
+func callTargetKind(req callRequest) string {
+	switch {
+	case req.WorkflowID != "":
+		return "workflow"
+	case req.SquadID != "":
+		return "squad"
+	default:
+		return "assistant"
+	}
+}
+
+// endedReasonExitCode maps a call's ended reason to the exit status of
+// 'call create --wait'
+func endedReasonExitCode(reason string) int {
+	switch {
+	case completedReasons[reason]:
+		return callExitCompleted
+	case notAnsweredReasons[reason]:
+		return callExitNotAnswered
+	default:
+		return callExitFailed
+	}
+}
+
+func init() {
+	callCmd.AddCommand(createCallCmd)
+
+	createCallCmd.Flags().StringVar(&callAssistant, "assistant", "", "Assistant ID or name to run the call")
+	createCallCmd.Flags().StringVar(&callWorkflow, "workflow", "", "Workflow ID or name to run the call")
+	createCallCmd.Flags().StringVar(&callSquad, "squad", "", "Squad ID or name to run the call")
+	createCallCmd.Flags().StringVar(&callPhoneNumber, "phone-number", "", "Vapi phone number ID, name or number to call from")
+	createCallCmd.Flags().StringVar(&callTo, "to", "", "Customer number to call, in E.164 form (e.g. +14155550123)")
+	createCallCmd.Flags().StringVar(&callCustomerName, "customer-name", "", "Customer name")
+	createCallCmd.Flags().StringArrayVar(&callVariables, "variable", nil, "Template variable for the call (key=value, repeatable)")
+	createCallCmd.Flags().StringArrayVar(&callMetadata, "metadata", nil, "Metadata for the call (key=value, repeatable)")
+	createCallCmd.Flags().StringVar(&callOverridesFile, "overrides-file", "", "JSON or YAML file of assistant (or workflow) overrides")
+	createCallCmd.Flags().BoolVar(&callWait, "wait", false, "Follow the call until it ends and exit with a status based on how it ended")
+	createCallCmd.Flags().Bool("no-validate", false, "Send --overrides-file without checking it against the API schema")
+}
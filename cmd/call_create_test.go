@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCallRequest(t *testing.T) {
+	body, err := buildCallRequest(callRequest{
+		AssistantID:   "a1",
+		PhoneNumberID: "p1",
+		To:            "+14155550123",
+		CustomerName:  "Ada",
+		Variables:     []string{"plan=pro", "greeting=Hi=there"},
+		Metadata:      []string{"ticket=QA-42"},
+		Overrides: map[string]interface{}{
+			"firstMessage":   "Hello {{name}}",
+			"variableValues": map[string]interface{}{"name": "Ada", "plan": "free"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "a1", body["assistantId"])
+	assert.Equal(t, "p1", body["phoneNumberId"])
+	assert.Equal(t, map[string]interface{}{"number": "+14155550123", "name": "Ada"}, body["customer"])
+	assert.Equal(t, map[string]interface{}{
+		"firstMessage":   "Hello {{name}}",
+		"variableValues": map[string]interface{}{"name": "Ada", "plan": "pro", "greeting": "Hi=there"},
+		"metadata":       map[string]interface{}{"ticket": "QA-42"},
+	}, body["assistantOverrides"])
+	assert.NotContains(t, body, "workflowOverrides")
+
+	body, err = buildCallRequest(callRequest{WorkflowID: "w1", PhoneNumberID: "p1", To: "+14155550123", Variables: []string{"plan=pro"}})
+	require.NoError(t, err)
+	assert.Equal(t, "w1", body["workflowId"])
+	assert.NotContains(t, body, "assistantOverrides")
+	assert.Equal(t, map[string]interface{}{"variableValues": map[string]interface{}{"plan": "pro"}}, body["workflowOverrides"])
+
+	body, err = buildCallRequest(callRequest{SquadID: "s1", PhoneNumberID: "p1", To: "+14155550123"})
+	require.NoError(t, err)
+	assert.Equal(t, "s1", body["squadId"])
+	assert.NotContains(t, body, "assistantOverrides")
+	assert.Equal(t, map[string]interface{}{"number": "+14155550123"}, body["customer"])
+
+	_, err = buildCallRequest(callRequest{WorkflowID: "w1", Metadata: []string{"a=b"}})
+	assert.ErrorContains(t, err, "not supported for workflow")
+
+	for _, req := range []callRequest{
+		{SquadID: "s1", Variables: []string{"plan=pro"}},
+		{SquadID: "s1", Metadata: []string{"a=b"}},
+		{SquadID: "s1", Overrides: map[string]interface{}{}},
+	} {
+		_, err = buildCallRequest(req)
+		assert.ErrorContains(t, err, "not supported for squad calls")
+	}
+
+	_, err = buildCallRequest(callRequest{AssistantID: "a1", Variables: []string{"plan"}})
+	assert.ErrorContains(t, err, "invalid --variable")
+}
+
+func TestBuildCallRequestKeepsUnknownOverrides(t *testing.T) {
+	// --no-validate lets keys the SDK doesn't model through; they must
+	// reach the request body
+	body, err := buildCallRequest(callRequest{
+		AssistantID:   "a1",
+		PhoneNumberID: "p1",
+		To:            "+14155550123",
+		Overrides:     map[string]interface{}{"brandNewSetting": map[string]interface{}{"enabled": true}},
+	})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"assistantOverrides":{"brandNewSetting":{"enabled":true}}`)
+}
+
+func TestCallDTO(t *testing.T) {
+	body, err := buildCallRequest(callRequest{
+		AssistantID:   "a1",
+		PhoneNumberID: "p1",
+		To:            "+14155550123",
+		CustomerName:  "Ada",
+		Variables:     []string{"plan=pro"},
+		Metadata:      []string{"ticket=QA-42"},
+		Overrides:     map[string]interface{}{"firstMessage": "Hello {{name}}"},
+	})
+	require.NoError(t, err)
+
+	dto, err := callDTO(body)
+	require.NoError(t, err)
+	require.NotNil(t, dto.AssistantId)
+	assert.Equal(t, "a1", *dto.AssistantId)
+	require.NotNil(t, dto.PhoneNumberId)
+	assert.Equal(t, "p1", *dto.PhoneNumberId)
+	assert.Equal(t, "+14155550123", *dto.Customer.GetNumber())
+	assert.Equal(t, "Ada", *dto.Customer.GetName())
+	assert.Equal(t, "Hello {{name}}", *dto.AssistantOverrides.GetFirstMessage())
+	assert.Equal(t, map[string]interface{}{"plan": "pro"}, dto.AssistantOverrides.GetVariableValues())
+	assert.Equal(t, map[string]interface{}{"ticket": "QA-42"}, dto.AssistantOverrides.GetMetadata())
+}
+
+func TestE164Pattern(t *testing.T) {
+	for _, number := range []string{"+14155550123", "+442071838750", "+12"} {
+		assert.True(t, e164Pattern.MatchString(number), number)
+	}
+	for _, number := range []string{"14155550123", "+1 415 555 0123", "+1-415-555-0123", "+04155550123", "+1234567890123456", ""} {
+		assert.False(t, e164Pattern.MatchString(number), number)
+	}
+}
+
+func TestEndedReasonExitCode(t *testing.T) {
+	assert.Equal(t, callExitCompleted, endedReasonExitCode("customer-ended-call"))
+	assert.Equal(t, callExitCompleted, endedReasonExitCode("assistant-said-end-call-phrase"))
+	assert.Equal(t, callExitNotAnswered, endedReasonExitCode("customer-did-not-answer"))
+	assert.Equal(t, callExitNotAnswered, endedReasonExitCode("voicemail"))
+	assert.Equal(t, callExitFailed, endedReasonExitCode("pipeline-error-openai-llm-failed"))
+	assert.Equal(t, callExitFailed, endedReasonExitCode(""))
+}
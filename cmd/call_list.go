@@ -0,0 +1,372 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	vapi "github.com/VapiAI/server-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+// callTypes maps the --type shorthands to the API's call types
+var callTypes = map[string]string{
+	"inbound":  "inboundPhoneCall",
+	"outbound": "outboundPhoneCall",
+	"web":      "webCall",
+}
+
+var callStatuses = []string{"scheduled", "queued", "ringing", "in-progress", "forwarding", "ended"}
+
+// defaultCallScan bounds how many calls client-side filters look through,
+// so a filter that rarely matches doesn't walk the whole call history
+const defaultCallScan = 1000
+
+// callFilter holds the 'call list' filters the API can't apply, checked
+// against each call as it is listed
+type callFilter struct {
+	Status      string
+	Type        string // API call type, e.g. "outboundPhoneCall"
+	EndedReason string // Exact reason or a prefix such as "pipeline-error"
+	Customer    string // Customer number, compared by digits
+	MinDuration time.Duration
+}
+
+var listCallsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all calls",
+	Long: `Display your call history as a table with each call's type, status, ended
+reason, customer, duration and cost.
+
+--assistant, --phone-number and the time window (--since/--until, or
+--created-after/--created-before) are applied by the API. --status, --type,
+--ended-reason, --customer and --min-duration are applied to each call as it
+is listed; --limit counts the calls that match. These filters look through at
+most --max-scan calls (default 1000), newest first; narrow the window with
+--since or raise --max-scan to search further back.
+
+--since and --until accept timestamps or durations relative to now, such as
+90m, 24h or 7d. --ended-reason matches an exact reason or a prefix, so
+"pipeline-error" finds every pipeline failure.`,
+	Example: `  vapi call list --since 24h --status ended --min-duration 30s
+  vapi call list --assistant support --type outbound --ended-reason pipeline-error
+  vapi call list --customer +14155550123 --all --max-scan 0 -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		fmt.Fprintln(os.Stderr, "Listing calls...")
+
+		opts, err := callListOptions(cmd)
+		if err != nil {
+			return err
+		}
+		filter, err := callFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		server := &vapi.CallsListRequest{}
+		query := url.Values{}
+		if ref, _ := cmd.Flags().GetString("assistant"); ref != "" {
+			id, err := vapiClient.Resolve(ctx, client.Assistants, ref)
+			if err != nil {
+				return err
+			}
+			server.AssistantId = &id
+			query.Set("assistantId", id)
+		}
+		if ref, _ := cmd.Flags().GetString("phone-number"); ref != "" {
+			id, err := vapiClient.Resolve(ctx, client.PhoneNumbers, ref)
+			if err != nil {
+				return err
+			}
+			server.PhoneNumberId = &id
+			query.Set("phoneNumberId", id)
+		}
+
+		// With client-side filters the iterator pages until enough calls
+		// match, rather than stopping after --limit calls are fetched, but
+		// never past --max-scan calls
+		limit := opts.Limit
+		maxScan := 0
+		if filter.active() {
+			opts.Limit = 0
+			if maxScan, _ = cmd.Flags().GetInt("max-scan"); maxScan < 0 {
+				return fmt.Errorf("--max-scan must be 0 or more")
+			}
+		}
+
+		stream := output.NewStream([]string{"ID", "TYPE", "STATUS", "ENDED REASON", "CUSTOMER", "CREATED", "DURATION", "COST"})
+		defer stream.Abort()
+		scan := &callScan{limit: limit, maxScan: maxScan}
+		add := func(item interface{}) error {
+			scan.scanned++
+			doc, err := callDocument(item)
+			if err != nil {
+				return err
+			}
+			if !filter.match(doc) {
+				return nil
+			}
+			scan.matched++
+			if err := stream.Add(item, callRow(doc)); err != nil {
+				return fmt.Errorf("failed to display calls: %w", err)
+			}
+			return nil
+		}
+
+		it := vapiClient.ListCalls(opts, server)
+		for !scan.done() && it.Next(ctx) {
+			if err := add(it.Item()); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			warnRawFallback(err)
+			raw := client.ResumeRaw(it, vapiClient.RawPages("/call", query))
+			for !scan.done() && raw.Next(ctx) {
+				if err := add(raw.Item()); err != nil {
+					return err
+				}
+			}
+			if err := raw.Err(); err != nil {
+				return fmt.Errorf("failed to list calls: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list calls: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display calls: %w", err)
+		}
+		if scan.truncated() {
+			fmt.Fprintf(os.Stderr, "\n⚠️  Stopped after scanning the %d most recent calls; older calls may match too.\n", scan.scanned)
+			fmt.Fprintln(os.Stderr, "   Narrow the window with --since/--until or raise --max-scan (0 scans everything).")
+		}
+
+		if scan.matched == 0 {
+			fmt.Fprintln(os.Stderr, "No calls found.")
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "\nFound %d call(s)\n", scan.matched)
+		return nil
+	},
+}
+
+// callScan tracks how far 'call list' has read: the calls fetched and the
+// calls that passed the filters
+type callScan struct {
+	limit   int // Matching calls wanted, 0 for all
+	maxScan int // Calls to look through, 0 for no cap
+	matched int
+	scanned int
+}
+
+func (s *callScan) done() bool {
+	return (s.limit > 0 && s.matched >= s.limit) || s.capped()
+}
+
+func (s *callScan) capped() bool {
+	return s.maxScan > 0 && s.scanned >= s.maxScan
+}
+
+// truncated reports whether the scan stopped at --max-scan before finding
+// all the calls asked for
+func (s *callScan) truncated() bool {
+	return s.capped() && (s.limit == 0 || s.matched < s.limit)
+}
+
+// callListOptions reads the pagination flags, with --since and --until as
+// shorter names for --created-after and --created-before
+func callListOptions(cmd *cobra.Command) (client.ListOptions, error) {
+	opts, err := listOptionsFromFlags(cmd)
+	if err != nil {
+		return opts, err
+	}
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	if since != "" && cmd.Flags().Changed("created-after") {
+		return opts, fmt.Errorf("--since and --created-after cannot be used together")
+	}
+	if until != "" && cmd.Flags().Changed("created-before") {
+		return opts, fmt.Errorf("--until and --created-before cannot be used together")
+	}
+	if since != "" {
+		if opts.CreatedAfter, err = parseTimeFlag(since); err != nil {
+			return opts, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		if opts.CreatedBefore, err = parseTimeFlag(until); err != nil {
+			return opts, fmt.Errorf("invalid --until: %w", err)
+		}
+	}
+	if opts.CreatedAfter != nil && opts.CreatedBefore != nil && !opts.CreatedAfter.Before(*opts.CreatedBefore) {
+		return opts, fmt.Errorf("--since must be earlier than --until")
+	}
+	return opts, nil
+}
+
+// callFilterFromFlags reads and checks the client-side filters of 'call list'
+func callFilterFromFlags(cmd *cobra.Command) (callFilter, error) {
+	var f callFilter
+	f.Status, _ = cmd.Flags().GetString("status")
+	callType, _ := cmd.Flags().GetString("type")
+	f.EndedReason, _ = cmd.Flags().GetString("ended-reason")
+	f.Customer, _ = cmd.Flags().GetString("customer")
+	minDuration, _ := cmd.Flags().GetString("min-duration")
+
+	if f.Status != "" && !containsString(callStatuses, f.Status) {
+		return f, fmt.Errorf("invalid --status %q (valid: %s)", f.Status, strings.Join(callStatuses, ", "))
+	}
+	if callType != "" {
+		var ok bool
+		if f.Type, ok = callTypes[callType]; !ok {
+			return f, fmt.Errorf("invalid --type %q (valid: inbound, outbound, web)", callType)
+		}
+	}
+	if f.Customer != "" && digits(f.Customer) == "" {
+		return f, fmt.Errorf("invalid --customer %q: expected a phone number", f.Customer)
+	}
+	if minDuration != "" {
+		d, err := time.ParseDuration(minDuration)
+		if err != nil || d < 0 {
+			return f, fmt.Errorf("invalid --min-duration %q: expected a duration such as 30s or 2m", minDuration)
+		}
+		f.MinDuration = d
+	}
+	return f, nil
+}
+
+func (f callFilter) active() bool {
+	return f != callFilter{}
+}
+
+// match reports whether a call, as loosely typed JSON, passes every filter
+func (f callFilter) match(call map[string]interface{}) bool {
+	if f.Status != "" && rawField(call, "status", "") != f.Status {
+		return false
+	}
+	if f.Type != "" && rawField(call, "type", "") != f.Type {
+		return false
+	}
+	if f.EndedReason != "" && !strings.HasPrefix(rawField(call, "endedReason", ""), f.EndedReason) {
+		return false
+	}
+	if f.Customer != "" && digits(rawField(call, "customer.number", "")) != digits(f.Customer) {
+		return false
+	}
+	if f.MinDuration > 0 {
+		d, ok := callDuration(call)
+		if !ok || d < f.MinDuration {
+			return false
+		}
+	}
+	return true
+}
+
+// callRow is a call's row in the 'call list' table
+func callRow(call map[string]interface{}) []string {
+	callType := rawField(call, "type", "")
+	for short, full := range callTypes {
+		if callType == full {
+			callType = short
+		}
+	}
+	duration := ""
+	if d, ok := callDuration(call); ok {
+		duration = formatCallDuration(d)
+	}
+	cost := ""
+	if c, ok := call["cost"].(float64); ok {
+		cost = formatCost(c)
+	}
+	return []string{
+		rawField(call, "id", ""),
+		callType,
+		rawField(call, "status", ""),
+		rawField(call, "endedReason", ""),
+		rawField(call, "customer.number", ""),
+		rawTime(call, "createdAt", "2006-01-02 15:04"),
+		duration,
+		cost,
+	}
+}
+
+// callDuration is the time between a call starting and ending
+func callDuration(call map[string]interface{}) (time.Duration, bool) {
+	started, err := time.Parse(time.RFC3339Nano, rawField(call, "startedAt", ""))
+	if err != nil {
+		return 0, false
+	}
+	ended, err := time.Parse(time.RFC3339Nano, rawField(call, "endedAt", ""))
+	if err != nil || ended.Before(started) {
+		return 0, false
+	}
+	return ended.Sub(started), true
+}
+
+func formatCallDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+func formatCost(cost float64) string {
+	return fmt.Sprintf("$%.4f", cost)
+}
+
+// digits keeps only the digits of a phone number, so "+1 (415) 555-0123"
+// and "+14155550123" compare equal
+func digits(number string) string {
+	var b strings.Builder
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// addCallFilterFlags registers the filters of 'call list'
+func addCallFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("assistant", "", "Only calls handled by this assistant (ID or name)")
+	cmd.Flags().String("phone-number", "", "Only calls on this Vapi phone number (ID, name or number)")
+	cmd.Flags().String("status", "", "Only calls with this status: "+strings.Join(callStatuses, ", "))
+	cmd.Flags().String("type", "", "Only calls of this type: inbound, outbound or web")
+	cmd.Flags().String("ended-reason", "", "Only calls whose ended reason is or starts with this value")
+	cmd.Flags().String("since", "", "Only calls created after this time (RFC3339, YYYY-MM-DD or relative like 24h, 7d)")
+	cmd.Flags().String("until", "", "Only calls created before this time (RFC3339, YYYY-MM-DD or relative like 24h, 7d)")
+	cmd.Flags().String("customer", "", "Only calls with this customer number")
+	cmd.Flags().String("min-duration", "", "Only calls that lasted at least this long (e.g. 30s, 2m)")
+	cmd.Flags().Int("max-scan", defaultCallScan, "Most calls --status, --type, --ended-reason, --customer and --min-duration look through, 0 for no limit")
+}
+
+func init() {
+	callCmd.AddCommand(listCallsCmd)
+
+	addListFlags(listCallsCmd)
+	addCallFilterFlags(listCallsCmd)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCallListFlags(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "list"}
+	addListFlags(cmd)
+	addCallFilterFlags(cmd)
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestCallListOptions(t *testing.T) {
+	opts, err := callListOptions(parseCallListFlags(t, "--since", "24h", "--until", "2h"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), *opts.CreatedAfter, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(-2*time.Hour), *opts.CreatedBefore, time.Minute)
+
+	_, err = callListOptions(parseCallListFlags(t, "--since", "24h", "--created-after", "7d"))
+	assert.ErrorContains(t, err, "cannot be used together")
+
+	_, err = callListOptions(parseCallListFlags(t, "--since", "1h", "--until", "24h"))
+	assert.ErrorContains(t, err, "--since must be earlier than --until")
+
+	_, err = callListOptions(parseCallListFlags(t, "--until", "tomorrow"))
+	assert.ErrorContains(t, err, "invalid --until")
+}
+
+func TestCallFilter(t *testing.T) {
+	f, err := callFilterFromFlags(parseCallListFlags(t))
+	require.NoError(t, err)
+	assert.False(t, f.active())
+
+	f, err = callFilterFromFlags(parseCallListFlags(t,
+		"--status", "ended", "--type", "outbound", "--ended-reason", "pipeline-error",
+		"--customer", "+1 (415) 555-0123", "--min-duration", "30s",
+	))
+	require.NoError(t, err)
+	assert.True(t, f.active())
+	assert.Equal(t, "outboundPhoneCall", f.Type)
+
+	call := map[string]interface{}{
+		"id":          "c1",
+		"type":        "outboundPhoneCall",
+		"status":      "ended",
+		"endedReason": "pipeline-error-openai-llm-failed",
+		"customer":    map[string]interface{}{"number": "+14155550123"},
+		"createdAt":   "2025-01-01T12:00:00Z",
+		"startedAt":   "2025-01-01T12:00:05Z",
+		"endedAt":     "2025-01-01T12:01:37.6Z",
+		"cost":        0.0821,
+	}
+	assert.True(t, f.match(call))
+
+	short := map[string]interface{}{}
+	for k, v := range call {
+		short[k] = v
+	}
+	short["endedAt"] = "2025-01-01T12:00:20Z"
+	assert.False(t, f.match(short))
+
+	other := map[string]interface{}{}
+	for k, v := range call {
+		other[k] = v
+	}
+	other["customer"] = map[string]interface{}{"number": "+14155550999"}
+	assert.False(t, f.match(other))
+
+	assert.Equal(t, []string{"c1", "outbound", "ended", "pipeline-error-openai-llm-failed", "+14155550123", "2025-01-01 12:00", "1m33s", "$0.0821"}, callRow(call))
+
+	for _, args := range [][]string{
+		{"--status", "done"},
+		{"--type", "sip"},
+		{"--customer", "ada"},
+		{"--min-duration", "long"},
+	} {
+		_, err := callFilterFromFlags(parseCallListFlags(t, args...))
+		assert.Error(t, err, args)
+	}
+}
+
+func TestCallScan(t *testing.T) {
+	// Enough matches before the cap
+	scan := &callScan{limit: 2, maxScan: 5, matched: 2, scanned: 3}
+	assert.True(t, scan.done())
+	assert.False(t, scan.truncated())
+
+	// The cap stops a filter that rarely matches
+	scan = &callScan{limit: 2, maxScan: 5, matched: 1, scanned: 5}
+	assert.True(t, scan.done())
+	assert.True(t, scan.truncated())
+
+	// --max-scan 0 scans everything
+	scan = &callScan{limit: 0, maxScan: 0, matched: 0, scanned: 100000}
+	assert.False(t, scan.done())
+	assert.False(t, scan.truncated())
+}
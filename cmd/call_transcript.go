@@ -0,0 +1,127 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/output"
+	"github.com/VapiAI/cli/pkg/transcript"
+)
+
+var (
+	transcriptFormat string
+	transcriptRedact bool
+)
+
+// Print a call's conversation without the rest of the call object
+var transcriptCallCmd = &cobra.Command{
+	Use:   "transcript [call-id]",
+	Short: "Print a call's transcript as text, Markdown, subtitles or JSON",
+	Long: `Turn a call's messages into a readable transcript.
+
+Each turn is labelled with its speaker and the time since the call started.
+Tool calls (→) and their results (←) are shown inline. The system prompt is
+left out.
+
+Formats:
+  text  one line per turn (default)
+  md    a Markdown list, ready to paste into a bug report
+  srt   SubRip subtitles of the spoken turns
+  vtt   WebVTT subtitles of the spoken turns, with speaker voice tags
+  json  the turns as a JSON array with offsets in seconds
+
+--redact replaces phone numbers, email addresses and card numbers with
+[phone], [email] and [card] before anything is printed. Other numbers of
+card length become [number].`,
+	Example: `  vapi call transcript <call-id>
+  vapi call transcript <call-id> --format md --redact | pbcopy
+  vapi call transcript <call-id> --format vtt > demo.vtt`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("call", "transcript", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		callID := args[0]
+
+		format := strings.ToLower(transcriptFormat)
+		if format != "json" && !isTranscriptFormat(format) {
+			return fmt.Errorf("invalid --format %q (valid: text, md, srt, vtt, json)", transcriptFormat)
+		}
+
+		state, err := fetchCallState(ctx, callID)
+		if err != nil {
+			return err
+		}
+		doc, err := callDocument(state.Call)
+		if err != nil {
+			return err
+		}
+
+		turns := callTurns(doc)
+		if transcriptRedact {
+			turns = transcript.Redact(turns)
+		}
+		if len(turns) == 0 {
+			fmt.Fprintf(os.Stderr, "Call %s has no transcript yet.\n", callID)
+		}
+
+		if format == "json" {
+			if turns == nil {
+				turns = []transcript.Turn{}
+			}
+			if err := output.Render(turns, nil); err != nil {
+				return fmt.Errorf("failed to display transcript: %w", err)
+			}
+			return nil
+		}
+		return transcript.Write(cmd.OutOrStdout(), turns, transcript.Format(format), "Call "+callID)
+	}),
+}
+
+// callTurns returns the transcript of a call, as loosely typed JSON, without
+// its system prompt
+func callTurns(call map[string]interface{}) []transcript.Turn {
+	var turns []transcript.Turn
+	for _, turn := range transcript.Parse(callMessages(call)) {
+		if turn.Kind != transcript.KindSystem {
+			turns = append(turns, turn)
+		}
+	}
+	return turns
+}
+
+func isTranscriptFormat(format string) bool {
+	for _, f := range transcript.Formats {
+		if string(f) == format {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	callCmd.AddCommand(transcriptCallCmd)
+
+	transcriptCallCmd.Flags().StringVar(&transcriptFormat, "format", "text", "Transcript format: text, md, srt, vtt or json")
+	transcriptCallCmd.Flags().BoolVar(&transcriptRedact, "redact", false, "Replace phone numbers, emails and card numbers")
+}
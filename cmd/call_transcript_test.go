@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/transcript"
+)
+
+func TestCallTurns(t *testing.T) {
+	call := decodeCall(t, `{"artifact": {"messages": [
+		{"role": "system", "message": "prompt", "secondsFromStart": 0},
+		{"role": "bot", "message": "Hello", "secondsFromStart": 1},
+		{"role": "user", "message": "Reach me at ada@example.com", "secondsFromStart": 3}
+	]}}`)
+
+	turns := callTurns(call)
+	require.Len(t, turns, 2)
+	assert.Equal(t, transcript.KindAssistant, turns[0].Kind)
+	assert.Equal(t, "Reach me at [email]", transcript.Redact(turns)[1].Text)
+
+	assert.Empty(t, callTurns(decodeCall(t, `{"status": "queued"}`)))
+	assert.True(t, isTranscriptFormat("vtt"))
+	assert.False(t, isTranscriptFormat("json"))
+}
@@ -0,0 +1,249 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	vapi "github.com/VapiAI/server-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/transcript"
+)
+
+var watchJSON bool
+
+// Polling starts fast and slows down while nothing changes, so a long call
+// doesn't cost hundreds of requests
+const (
+	watchMinInterval = time.Second
+	watchMaxInterval = 10 * time.Second
+)
+
+// callEvent is one change seen while watching a call
+type callEvent struct {
+	Type    string           `json:"type"` // "status", "message" or "summary"
+	Time    time.Time        `json:"time"`
+	CallID  string           `json:"callId"`
+	Status  string           `json:"status,omitempty"`
+	Turn    *transcript.Turn `json:"turn,omitempty"`
+	Summary *callSummary     `json:"summary,omitempty"`
+}
+
+// callSummary describes a call that has ended
+type callSummary struct {
+	DurationSeconds float64  `json:"durationSeconds"`
+	Cost            *float64 `json:"cost,omitempty"`
+	EndedReason     string   `json:"endedReason,omitempty"`
+	RecordingURL    string   `json:"recordingUrl,omitempty"`
+}
+
+// callWatcher turns successive snapshots of a call into events
+type callWatcher struct {
+	status   string
+	messages int
+}
+
+// Follow a call live from the terminal
+var watchCallCmd = &cobra.Command{
+	Use:   "watch [call-id]",
+	Short: "Follow a call's status and transcript as it happens",
+	Long: `Follow a call until it ends.
+
+Status changes are printed with the time they were seen, followed by new
+transcript messages as they appear. When the call ends a summary with its
+duration, cost, ended reason and recording URL is printed. Press Ctrl+C to
+stop watching; the call itself is not affected.
+
+With --json every change is printed as one JSON object per line (NDJSON)
+with a "type" of status, message or summary.`,
+	Example: `  vapi call watch <call-id>
+  vapi call watch <call-id> --json | jq -c 'select(.type == "message") | .turn'`,
+	Args: cobra.ExactArgs(1),
+	RunE: analytics.TrackCommandWrapper("call", "watch", func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		callID := args[0]
+		out := cmd.OutOrStdout()
+
+		if !watchJSON {
+			fmt.Fprintf(os.Stderr, "👀 Watching call %s (Ctrl+C to stop)\n", callID)
+		}
+
+		watcher := &callWatcher{}
+		interval := watchMinInterval
+		for {
+			state, err := fetchCallState(ctx, callID)
+			if ctx.Err() != nil {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			doc, err := callDocument(state.Call)
+			if err != nil {
+				return err
+			}
+
+			events := watcher.observe(callID, doc, time.Now())
+			for _, ev := range events {
+				if err := writeCallEvent(out, ev, watchJSON); err != nil {
+					return err
+				}
+			}
+			if state.Status == string(vapi.CallStatusEnded) {
+				return nil
+			}
+
+			if len(events) > 0 {
+				interval = watchMinInterval
+			} else if interval *= 2; interval > watchMaxInterval {
+				interval = watchMaxInterval
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		if !watchJSON {
+			fmt.Fprintf(os.Stderr, "\nStopped watching call %s\n", callID)
+		}
+		return nil
+	}),
+}
+
+// observe compares a snapshot of a call with the previous one and returns
+// what changed: a new status, new transcript messages and, once the call
+// has ended, its summary
+func (w *callWatcher) observe(callID string, call map[string]interface{}, now time.Time) []callEvent {
+	var events []callEvent
+	status := rawField(call, "status", "")
+	if status != "" && status != w.status {
+		events = append(events, callEvent{Type: "status", Time: now, CallID: callID, Status: status})
+		w.status = status
+	}
+
+	messages := callMessages(call)
+	if len(messages) < w.messages {
+		// The API can swap live messages for the final artifact; only new
+		// messages are reported
+		w.messages = len(messages)
+	}
+	for _, turn := range transcript.Parse(messages[w.messages:]) {
+		if turn.Kind == transcript.KindSystem {
+			continue
+		}
+		turn := turn
+		events = append(events, callEvent{Type: "message", Time: now, CallID: callID, Turn: &turn})
+	}
+	w.messages = len(messages)
+
+	if status == string(vapi.CallStatusEnded) {
+		events = append(events, callEvent{Type: "summary", Time: now, CallID: callID, Summary: summarizeCall(call)})
+	}
+	return events
+}
+
+// callMessages returns a call's messages, which live under the artifact
+// while some API versions leave the top-level list empty
+func callMessages(call map[string]interface{}) []interface{} {
+	if messages, ok := call["messages"].([]interface{}); ok && len(messages) > 0 {
+		return messages
+	}
+	artifact, _ := call["artifact"].(map[string]interface{})
+	messages, _ := artifact["messages"].([]interface{})
+	return messages
+}
+
+func summarizeCall(call map[string]interface{}) *callSummary {
+	summary := &callSummary{
+		EndedReason:  rawField(call, "endedReason", ""),
+		RecordingURL: rawField(call, "artifact.recordingUrl", rawField(call, "recordingUrl", "")),
+	}
+	if cost, ok := call["cost"].(float64); ok {
+		summary.Cost = &cost
+	}
+	if d, ok := callDuration(call); ok {
+		summary.DurationSeconds = d.Seconds()
+	}
+	return summary
+}
+
+// writeCallEvent prints ev as a line of NDJSON or as text for a terminal
+func writeCallEvent(w io.Writer, ev callEvent, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(ev)
+	}
+	var err error
+	switch ev.Type {
+	case "status":
+		_, err = fmt.Fprintf(w, "%s  ● %s\n", ev.Time.Format("15:04:05"), ev.Status)
+	case "message":
+		_, err = fmt.Fprintf(w, "%s    %s\n", ev.Time.Format("15:04:05"), ev.Turn.Line())
+	case "summary":
+		s := ev.Summary
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Call ended")
+		fmt.Fprintf(w, "  Duration:     %s\n", formatCallDuration(time.Duration(s.DurationSeconds*float64(time.Second))))
+		if s.Cost != nil {
+			fmt.Fprintf(w, "  Cost:         %s\n", formatCost(*s.Cost))
+		}
+		fmt.Fprintf(w, "  Ended reason: %s\n", valueOr(s.EndedReason, "unknown"))
+		_, err = fmt.Fprintf(w, "  Recording:    %s\n", valueOr(s.RecordingURL, "none"))
+	}
+	return err
+}
+
+// callDocument converts a fetched call to loosely typed JSON, so calls
+// decoded by the SDK and raw fallbacks are read the same way
+func callDocument(call interface{}) (map[string]interface{}, error) {
+	if doc, ok := call.(map[string]interface{}); ok {
+		return doc, nil
+	}
+	b, err := json.Marshal(call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read call: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to read call: %w", err)
+	}
+	return doc, nil
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func init() {
+	callCmd.AddCommand(watchCallCmd)
+
+	watchCallCmd.Flags().BoolVar(&watchJSON, "json", false, "Print each change as a JSON object per line (NDJSON)")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeCall(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &doc))
+	return doc
+}
+
+func TestCallWatcherObserve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := &callWatcher{}
+
+	events := w.observe("c1", decodeCall(t, `{"status": "ringing"}`), now)
+	require.Len(t, events, 1)
+	assert.Equal(t, "ringing", events[0].Status)
+
+	assert.Empty(t, w.observe("c1", decodeCall(t, `{"status": "ringing"}`), now))
+
+	events = w.observe("c1", decodeCall(t, `{"status": "in-progress", "messages": [
+		{"role": "system", "message": "prompt", "secondsFromStart": 0},
+		{"role": "bot", "message": "Hello", "secondsFromStart": 1}
+	]}`), now)
+	require.Len(t, events, 2)
+	assert.Equal(t, "in-progress", events[0].Status)
+	assert.Equal(t, "Hello", events[1].Turn.Text)
+
+	events = w.observe("c1", decodeCall(t, `{
+		"status": "ended", "endedReason": "customer-ended-call", "cost": 0.12,
+		"startedAt": "2025-01-01T12:00:00Z", "endedAt": "2025-01-01T12:01:30.4Z",
+		"artifact": {"recordingUrl": "https://example.com/r.wav", "messages": [
+			{"role": "system", "message": "prompt", "secondsFromStart": 0},
+			{"role": "bot", "message": "Hello", "secondsFromStart": 1},
+			{"role": "user", "message": "Bye", "secondsFromStart": 4}
+		]}
+	}`), now)
+	require.Len(t, events, 3)
+	assert.Equal(t, "ended", events[0].Status)
+	assert.Equal(t, "Bye", events[1].Turn.Text)
+	require.NotNil(t, events[2].Summary)
+	assert.Equal(t, &callSummary{DurationSeconds: 90.4, Cost: ptr(0.12), EndedReason: "customer-ended-call", RecordingURL: "https://example.com/r.wav"}, events[2].Summary)
+
+	var buf bytes.Buffer
+	for _, ev := range events {
+		require.NoError(t, writeCallEvent(&buf, ev, false))
+	}
+	text := buf.String()
+	assert.Contains(t, text, "12:00:00  ● ended")
+	assert.Contains(t, text, "[00:04] Customer: Bye")
+	assert.Contains(t, text, "Duration:     1m30s")
+	assert.Contains(t, text, "Cost:         $0.1200")
+
+	buf.Reset()
+	for _, ev := range events {
+		require.NoError(t, writeCallEvent(&buf, ev, true))
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.JSONEq(t, `{"type":"message","time":"2025-01-01T12:00:00Z","callId":"c1","turn":{"kind":"customer","speaker":"Customer","text":"Bye","start":4,"end":4}}`, lines[1])
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
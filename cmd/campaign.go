@@ -19,13 +19,15 @@ Authors:
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/AlecAivazis/survey/v2"
 	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
 )
 
 // Main campaign command
@@ -41,35 +43,54 @@ var campaignListCmd = &cobra.Command{
 	Short: "List all campaigns",
 	Long:  `Display all campaigns in your Vapi account with their status and details.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Fetch all campaigns from the API
-		campaignsResponse, err := vapiClient.GetClient().Campaigns.CampaignControllerFindAll(ctx, &vapi.CampaignControllerFindAllRequest{})
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to list campaigns: %w", err)
-		}
-
-		campaigns := campaignsResponse.Results
-		if len(campaigns) == 0 {
-			fmt.Println("No campaigns found. Create one with 'vapi campaign create'")
-			return nil
+			return err
 		}
 
-		// Display campaigns in a formatted table
-		fmt.Printf("%-36s %-20s %-15s %-15s %-20s\n", "ID", "Name", "Status", "Calls Ended", "Created")
-		fmt.Println(strings.Repeat("-", 110))
-
-		for _, campaign := range campaigns {
-			status := string(campaign.Status)
-			callsEnded := fmt.Sprintf("%.0f", campaign.CallsCounterEnded)
-			created := campaign.CreatedAt.Format("2006-01-02 15:04")
-
-			fmt.Printf("%-36s %-20s %-15s %-15s %-20s\n",
+		it := vapiClient.ListCampaigns(opts)
+		stream := output.NewStream([]string{"ID", "NAME", "STATUS", "CALLS ENDED", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			campaign := it.Item()
+			row := []string{
 				campaign.Id,
 				truncateString(campaign.Name, 20),
-				status,
-				callsEnded,
-				created)
+				string(campaign.Status),
+				fmt.Sprintf("%.0f", campaign.CallsCounterEnded),
+				campaign.CreatedAt.Format("2006-01-02 15:04"),
+			}
+			if err := stream.Add(campaign, row); err != nil {
+				return fmt.Errorf("failed to display campaigns: %w", err)
+			}
+		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawPages("/campaign", nil), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					truncateString(rawField(item, "name", ""), 20),
+					rawField(item, "status", ""),
+					rawField(item, "callsCounterEnded", "0"),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list campaigns: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list campaigns: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display campaigns: %w", err)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No campaigns found. Create one with 'vapi campaign create'")
 		}
 
 		return nil
@@ -82,7 +103,7 @@ var campaignCreateCmd = &cobra.Command{
 	Short: "Create a new campaign",
 	Long:  `Create a new campaign for automated AI phone calls.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Interactive campaign creation
 		var name string
@@ -282,47 +303,28 @@ var campaignCreateCmd = &cobra.Command{
 
 // Campaign get command
 var campaignGetCmd = &cobra.Command{
-	Use:   "get [campaign-id]",
+	Use:   "get [campaign-id|name]",
 	Short: "Get campaign details",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		campaignID := args[0]
+		ctx := cmd.Context()
+		campaignID, err := vapiClient.Resolve(ctx, client.Campaigns, args[0])
+		if err != nil {
+			return err
+		}
 
 		// Fetch the campaign
 		campaign, err := vapiClient.GetClient().Campaigns.CampaignControllerFindOne(ctx, campaignID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/campaign/"+campaignID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get campaign: %w", err)
 		}
 
-		// Display campaign details
-		fmt.Printf("Campaign Details:\n")
-		fmt.Printf("================\n")
-		fmt.Printf("ID: %s\n", campaign.Id)
-		fmt.Printf("Name: %s\n", campaign.Name)
-		fmt.Printf("Status: %s\n", campaign.Status)
-		if campaign.EndedReason != nil {
-			fmt.Printf("Ended Reason: %s\n", *campaign.EndedReason)
-		}
-		fmt.Printf("Phone Number ID: %s\n", campaign.PhoneNumberId)
-
-		if campaign.AssistantId != nil {
-			fmt.Printf("Assistant ID: %s\n", *campaign.AssistantId)
-		}
-		if campaign.WorkflowId != nil {
-			fmt.Printf("Workflow ID: %s\n", *campaign.WorkflowId)
-		}
-
-		fmt.Printf("Calls Ended: %.0f\n", campaign.CallsCounterEnded)
-		fmt.Printf("Created: %s\n", campaign.CreatedAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("Updated: %s\n", campaign.UpdatedAt.Format("2006-01-02 15:04:05"))
-
-		if len(campaign.Customers) > 0 {
-			fmt.Printf("\nCustomers: %d\n", len(campaign.Customers))
-		}
-
-		if campaign.SchedulePlan != nil {
-			fmt.Printf("\nSchedule Plan configured\n")
+		// Display campaign details in the selected format (JSON by default)
+		if err := output.Render(campaign, nil); err != nil {
+			return fmt.Errorf("failed to display campaign: %w", err)
 		}
 
 		return nil
@@ -331,13 +333,33 @@ var campaignGetCmd = &cobra.Command{
 
 // Campaign update command
 var campaignUpdateCmd = &cobra.Command{
-	Use:   "update [campaign-id]",
+	Use:   "update [campaign-id|name]",
 	Short: "Update a campaign",
-	Long:  `Update campaign details. Note: Some fields can only be updated when campaign is not in progress.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Update campaign details. Note: Some fields can only be updated when campaign is not in progress.
+
+Without any flags, an in-progress campaign can be ended interactively.
+Provide the fields to change as JSON via --json or --file.`,
+	Example: `  vapi campaign update spring-promo --set name="Spring promo (final)"
+  vapi campaign update <campaign-id> --set status=ended`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		campaignID := args[0]
+		if hasUpdateFlags(cmd) {
+			campaign, err := updateFromPayload(cmd, client.Campaigns, args[0])
+			if err != nil || campaign == nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "✅ Campaign updated successfully")
+			if err := output.Render(campaign, nil); err != nil {
+				return fmt.Errorf("failed to display campaign: %w", err)
+			}
+			return nil
+		}
+
+		ctx := cmd.Context()
+		campaignID, err := vapiClient.Resolve(ctx, client.Campaigns, args[0])
+		if err != nil {
+			return err
+		}
 
 		// Fetch current campaign
 		campaign, err := vapiClient.GetClient().Campaigns.CampaignControllerFindOne(ctx, campaignID)
@@ -386,12 +408,15 @@ var campaignUpdateCmd = &cobra.Command{
 
 // Campaign delete command
 var campaignDeleteCmd = &cobra.Command{
-	Use:   "delete [campaign-id]",
+	Use:   "delete [campaign-id|name]",
 	Short: "Delete a campaign",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		campaignID := args[0]
+		ctx := cmd.Context()
+		campaignID, err := vapiClient.Resolve(ctx, client.Campaigns, args[0])
+		if err != nil {
+			return err
+		}
 
 		// Confirm deletion
 		var confirm bool
@@ -404,17 +429,18 @@ var campaignDeleteCmd = &cobra.Command{
 		}
 
 		if !confirm {
-			fmt.Println("Deletion canceled.")
+			fmt.Fprintln(os.Stderr, "Deletion canceled.")
 			return nil
 		}
 
 		// Execute deletion via API
-		_, err := vapiClient.GetClient().Campaigns.CampaignControllerRemove(ctx, campaignID)
+		_, err = vapiClient.GetClient().Campaigns.CampaignControllerRemove(ctx, campaignID)
 		if err != nil {
 			return fmt.Errorf("failed to delete campaign: %w", err)
 		}
+		vapiClient.ForgetNames(client.Campaigns)
 
-		fmt.Println("✅ Campaign deleted successfully!")
+		fmt.Fprintln(os.Stderr, "✅ Campaign deleted successfully!")
 		return nil
 	},
 }
@@ -434,4 +460,8 @@ func init() {
 	campaignCmd.AddCommand(campaignGetCmd)
 	campaignCmd.AddCommand(campaignUpdateCmd)
 	campaignCmd.AddCommand(campaignDeleteCmd)
+
+	addListFlags(campaignListCmd)
+
+	addUpdateFlags(campaignUpdateCmd, "campaign")
 }
@@ -19,14 +19,13 @@ Authors:
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/AlecAivazis/survey/v2"
-	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/output"
 )
 
@@ -48,41 +47,22 @@ var listChatCmd = &cobra.Command{
 	Short: "List all chat conversations",
 	Long:  `Display all chat conversations in your account with their IDs, status, and metadata.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("💬 Listing chat conversations...")
+		fmt.Fprintln(os.Stderr, "💬 Listing chat conversations...")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Fetch up to 50 chats from the API
-		listRequest := &vapi.ChatsListRequest{
-			Limit: vapi.Float64(50),
-		}
-
-		chats, err := vapiClient.GetClient().Chats.List(ctx, listRequest)
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			// Check if this is a deserialization error related to new features
-			if strings.Contains(err.Error(), "cannot be deserialized") {
-				fmt.Println("⚠️  Warning: The Vapi API returned data in a format not yet supported by this CLI version.")
-				fmt.Println("   This usually happens when new features are added to Vapi.")
-				fmt.Println("   Please check for CLI updates: https://github.com/VapiAI/cli/releases")
-				fmt.Println()
-				fmt.Printf("   Technical details: %v\n", err)
-				return fmt.Errorf("incompatible API response format")
-			}
-			return fmt.Errorf("failed to list chats: %w", err)
-		}
-
-		chatResults := chats.Results
-		if len(chatResults) == 0 {
-			fmt.Println("No chat conversations found. Create one with 'vapi chat create'")
-			return nil
+			return err
 		}
 
-		// Display in a readable table format
-		fmt.Printf("\nFound %d chat conversation(s):\n\n", len(chatResults))
-		fmt.Printf("%-36s %-30s %-36s %-20s\n", "ID", "Name", "Assistant ID", "Created")
-		fmt.Printf("%-36s %-30s %-36s %-20s\n", "----", "----", "------------", "-------")
+		// Stream chats page by page so long histories stay cheap
+		it := vapiClient.ListChats(opts)
+		stream := output.NewStream([]string{"ID", "NAME", "ASSISTANT ID", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			chat := it.Item()
 
-		for _, chat := range chatResults {
 			name := "Unnamed"
 			if chat.Name != nil && *chat.Name != "" {
 				name = *chat.Name
@@ -95,9 +75,38 @@ var listChatCmd = &cobra.Command{
 
 			created := chat.CreatedAt.Format("2006-01-02 15:04")
 
-			fmt.Printf("%-36s %-30s %-36s %-20s\n", chat.Id, name, assistantId, created)
+			if err := stream.Add(chat, []string{chat.Id, name, assistantId, created}); err != nil {
+				return fmt.Errorf("failed to display chats: %w", err)
+			}
+		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawPages("/chat", nil), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					rawField(item, "name", "Unnamed"),
+					rawField(item, "assistantId", "None"),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list chats: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list chats: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display chats: %w", err)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No chat conversations found. Create one with 'vapi chat create'")
+			return nil
 		}
 
+		fmt.Fprintf(os.Stderr, "\nFound %d chat conversation(s)\n", count)
 		return nil
 	},
 }
@@ -133,19 +142,22 @@ var getChatCmd = &cobra.Command{
 	Long:  `Retrieve the complete history and details of a chat conversation including all messages.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		chatID := args[0]
 
-		fmt.Printf("🔍 Getting chat conversation details for ID: %s\n", chatID)
+		fmt.Fprintf(os.Stderr, "🔍 Getting chat conversation details for ID: %s\n", chatID)
 
 		// Fetch the chat conversation
 		chat, err := vapiClient.GetClient().Chats.Get(ctx, chatID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/chat/"+chatID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get chat: %w", err)
 		}
 
-		// Display as formatted JSON for complete details
-		if err := output.PrintJSON(chat); err != nil {
+		// Display complete details in the selected format
+		if err := output.Render(chat, nil); err != nil {
 			return fmt.Errorf("failed to display chat: %w", err)
 		}
 
@@ -159,7 +171,7 @@ var deleteChatCmd = &cobra.Command{
 	Long:  `Permanently delete a chat conversation and all its messages. This cannot be undone.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		chatID := args[0]
 
 		// Require explicit confirmation for destructive actions
@@ -171,7 +183,7 @@ var deleteChatCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("🗑️  Deleting chat conversation with ID: %s\n", chatID)
+		fmt.Fprintf(os.Stderr, "🗑️  Deleting chat conversation with ID: %s\n", chatID)
 
 		// Execute deletion via API
 		_, err = vapiClient.GetClient().Chats.Delete(ctx, chatID)
@@ -179,7 +191,7 @@ var deleteChatCmd = &cobra.Command{
 			return fmt.Errorf("failed to delete chat: %w", err)
 		}
 
-		fmt.Println("✅ Chat conversation deleted successfully")
+		fmt.Fprintln(os.Stderr, "✅ Chat conversation deleted successfully")
 		return nil
 	},
 }
@@ -238,4 +250,6 @@ func init() {
 	chatCmd.AddCommand(getChatCmd)
 	chatCmd.AddCommand(deleteChatCmd)
 	chatCmd.AddCommand(continueChatCmd)
+
+	addListFlags(listChatCmd)
 }
@@ -21,6 +21,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -66,6 +67,12 @@ var configGetCmd = &cobra.Command{
 				fmt.Printf("environment: %s\n", cfg.GetEnvironment())
 			case "timeout":
 				fmt.Printf("timeout: %d\n", cfg.Timeout)
+			case "max_retries":
+				fmt.Printf("max_retries: %d\n", cfg.MaxRetries)
+			case "retry_max_delay":
+				fmt.Printf("retry_max_delay: %d\n", cfg.RetryMaxDelay)
+			case "retry_all_methods":
+				fmt.Printf("retry_all_methods: %t\n", cfg.RetryAllMethods)
 			default:
 				return fmt.Errorf("unknown config key: %s", key)
 			}
@@ -84,6 +91,9 @@ var configGetCmd = &cobra.Command{
 			fmt.Printf("base_url: %s\n", cfg.GetAPIBaseURL())
 			fmt.Printf("dashboard_url: %s\n", cfg.GetDashboardURL())
 			fmt.Printf("timeout: %d\n", cfg.Timeout)
+			fmt.Printf("max_retries: %d\n", cfg.MaxRetries)
+			fmt.Printf("retry_max_delay: %d\n", cfg.RetryMaxDelay)
+			fmt.Printf("retry_all_methods: %t\n", cfg.RetryAllMethods)
 
 			// Show environment variables if set (for developers)
 			if envVars := getRelevantEnvVars(); len(envVars) > 0 {
@@ -104,7 +114,7 @@ var configGetCmd = &cobra.Command{
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set configuration value",
-	Long:  `Set a configuration value. Available keys: api_key, timeout, environment`,
+	Long:  `Set a configuration value. Available keys: api_key, timeout, environment, max_retries, retry_max_delay, retry_all_methods`,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
@@ -125,6 +135,22 @@ var configSetCmd = &cobra.Command{
 				return fmt.Errorf("timeout must be a number: %w", err)
 			}
 			cfg.Timeout = timeout
+		case "max_retries", "retry_max_delay":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%s must be a non-negative number", key)
+			}
+			if key == "max_retries" {
+				cfg.MaxRetries = n
+			} else {
+				cfg.RetryMaxDelay = n
+			}
+		case "retry_all_methods":
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("retry_all_methods must be true or false: %w", err)
+			}
+			cfg.RetryAllMethods = enabled
 		case "environment":
 			// Validate environment
 			validEnvs := []string{"production", "staging", "development"}
@@ -153,6 +179,7 @@ var configSetCmd = &cobra.Command{
 		default:
 			return fmt.Errorf("unknown config key: %s", key)
 		}
+		cfg.Persist(key)
 
 		if err := config.SaveConfig(cfg); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
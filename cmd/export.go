@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/config"
+	"github.com/VapiAI/cli/pkg/snapshot"
+)
+
+var (
+	exportDir     string
+	exportAccount string
+
+	importDir     string
+	importAccount string
+	importMapping string
+)
+
+// Snapshot every resource of an account to a directory of JSON files
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tools, assistants, squads, workflows and phone numbers to a directory",
+	Long: `Write every tool, assistant, squad, workflow and phone number in the account
+to a directory, one JSON file per resource:
+
+  vapi-export/
+    export.json            # When and from which account the export was taken
+    tools/<id>.json
+    assistants/<id>.json
+    squads/<id>.json
+    workflows/<id>.json
+    phone-numbers/<id>.json
+
+Exporting into an existing directory replaces its previous contents, so the
+directory can be kept in version control as a backup.`,
+	Example: `  vapi export --dir vapi-export
+  vapi export --dir staging-backup --account staging`,
+	Args: cobra.NoArgs,
+	RunE: analytics.TrackCommandWrapper("export", "export", func(cmd *cobra.Command, args []string) error {
+		source := vapiClient
+		meta := snapshot.Metadata{}
+		if exportAccount != "" {
+			var err error
+			if source, err = client.NewVapiClientForAccount(exportAccount); err != nil {
+				return err
+			}
+			meta.Account = exportAccount
+		} else if cfg := config.GetConfig(); cfg != nil {
+			meta.Account = cfg.ActiveAccount
+		}
+		// The environment of the account actually exported
+		meta.Environment = source.GetConfig().GetEnvironment()
+
+		if err := os.MkdirAll(exportDir, 0o750); err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportDir, err)
+		}
+
+		result, err := snapshot.Export(cmd.Context(), source, exportDir, meta, os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		total := 0
+		for _, n := range result.Counts {
+			total += n
+		}
+		fmt.Fprintf(os.Stderr, "\n✅ Exported %d resource(s) to %s\n", total, exportDir)
+		return nil
+	}),
+}
+
+// Recreate an export in another account
+var importCmd = &cobra.Command{
+	Use:   "import --dir <dir> --account <account>",
+	Short: "Import an export into another account",
+	Long: `Recreate the resources from 'vapi export' in a configured account.
+
+Resources are created in dependency order (tools, assistants, squads,
+workflows) and every reference to an exported resource, such as an
+assistant's toolIds or a squad's members, is rewritten to the new ID.
+References to resources created later in the run are linked in a second
+pass. IDs of anything that isn't exported, such as credentialIds or a
+model's knowledgeBaseId, stop the import before it changes anything.
+
+Phone numbers can't be copied between accounts. Instead, each exported number
+is matched to the number or SIP URI already in the target account and only
+its routing (assistant, squad, workflow, server and fallback) is updated.
+Numbers missing from the target are skipped.
+
+The source → target ID mapping is saved after every create. Running the same
+import again updates the resources it created earlier rather than duplicating
+them.`,
+	Example: `  vapi import --dir vapi-export --account production
+  vapi import --dir vapi-export --account production --mapping prod-ids.json`,
+	Args: cobra.NoArgs,
+	RunE: analytics.TrackCommandWrapper("import", "import", func(cmd *cobra.Command, args []string) error {
+		target, err := client.NewVapiClientForAccount(importAccount)
+		if err != nil {
+			return err
+		}
+
+		mappingPath := importMapping
+		if mappingPath == "" {
+			mappingPath = filepath.Join(importDir, fmt.Sprintf("import-%s.json", importAccount))
+		}
+		mapping, err := snapshot.LoadMapping(mappingPath, importAccount)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "📥 Importing %s into account '%s'...\n\n", importDir, importAccount)
+		result, err := snapshot.Import(cmd.Context(), target, importDir, mapping, os.Stderr)
+		var scoped *snapshot.AccountScopedError
+		if errors.As(err, &scoped) {
+			return fmt.Errorf("%w\nPoint these fields at the target account's resources or remove them in the exported files, then import again", err)
+		}
+		if err != nil {
+			return fmt.Errorf("%w\nProgress was saved to %s; re-run the import to continue", err, mappingPath)
+		}
+
+		fmt.Fprintf(os.Stderr, "\n✅ Import complete: %d created, %d updated, %d skipped\n", result.Created, result.Updated, result.Skipped)
+		fmt.Fprintf(os.Stderr, "ID mapping saved to %s\n", mappingPath)
+		analytics.TrackEvent("import_success", map[string]interface{}{
+			"created": result.Created,
+			"updated": result.Updated,
+			"skipped": result.Skipped,
+		})
+		return nil
+	}),
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+
+	exportCmd.Flags().StringVar(&exportDir, "dir", "vapi-export", "Directory to write the export to")
+	exportCmd.Flags().StringVar(&exportAccount, "account", "", "Account to export (default: the active account)")
+
+	importCmd.Flags().StringVar(&importDir, "dir", "vapi-export", "Directory written by 'vapi export'")
+	importCmd.Flags().StringVar(&importAccount, "account", "", "Configured account to import into")
+	importCmd.Flags().StringVar(&importMapping, "mapping", "", "ID mapping file (default: <dir>/import-<account>.json)")
+	_ = importCmd.MarkFlagRequired("account")
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+// warnRawFallback explains that the SDK couldn't decode a response and the
+// raw API data is shown instead
+func warnRawFallback(err error) {
+	fmt.Fprintln(os.Stderr, "⚠️  Warning: The Vapi API returned data this CLI version doesn't fully understand yet.")
+	fmt.Fprintln(os.Stderr, "   Showing the raw API response instead. Run 'vapi update' for full support.")
+	fmt.Fprintf(os.Stderr, "   Technical details: %s\n", extractErrorSummary(err.Error()))
+	if viper.GetString("template") != "" {
+		fmt.Fprintln(os.Stderr, "   --template sees the raw response, so use JSON keys such as {{.id}} instead of {{.Id}}.")
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+// renderRawFallback re-fetches path through the raw HTTP path after the SDK
+// failed to decode it, and renders the result through the output layer
+func renderRawFallback(ctx context.Context, path string, sdkErr error) error {
+	warnRawFallback(sdkErr)
+	raw, err := vapiClient.GetRaw(ctx, path)
+	if err != nil {
+		return err
+	}
+	return output.Render(raw, nil)
+}
+
+// continueListRaw finishes a list whose SDK decoding failed part-way. The
+// remaining pages are re-issued through the raw HTTP path and added to the
+// same stream, so earlier rows are neither lost nor repeated. It returns how
+// many items were added.
+func continueListRaw[T any](ctx context.Context, it *client.Iterator[T], fetch client.PageFunc[map[string]interface{}], stream *output.Stream, row func(item map[string]interface{}) []string) (int, error) {
+	warnRawFallback(it.Err())
+
+	raw := client.ResumeRaw(it, fetch)
+	for raw.Next(ctx) {
+		item := raw.Item()
+		if err := stream.Add(item, row(item)); err != nil {
+			return raw.Count(), err
+		}
+	}
+	return raw.Count(), raw.Err()
+}
+
+// rawField reads a dotted JSON path from a loosely typed resource, returning
+// fallback when it is missing or empty
+func rawField(item map[string]interface{}, path, fallback string) string {
+	v, err := output.Query(item, path)
+	if err != nil || v == nil {
+		return fallback
+	}
+	if s := output.FormatValue(v); s != "" {
+		return s
+	}
+	return fallback
+}
+
+// rawTime reads an RFC 3339 timestamp from a loosely typed resource and
+// formats it with layout
+func rawTime(item map[string]interface{}, path, layout string) string {
+	value := rawField(item, path, "")
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return t.Format(layout)
+}
+
+// extractErrorSummary extracts a cleaner error message from deserialization errors
+func extractErrorSummary(errorMsg string) string {
+	// Extract the key part of the error message
+	if strings.Contains(errorMsg, "cannot be deserialized as") {
+		// Find the part before "cannot be deserialized"
+		parts := strings.Split(errorMsg, " cannot be deserialized")
+		if len(parts) > 0 {
+			// Get the last part which contains the data that failed
+			data := strings.TrimSpace(parts[0])
+			// Extract just the type or first part to avoid overwhelming output
+			if len(data) > 100 {
+				return data[:100] + "..."
+			}
+			return data
+		}
+	}
+	// Fallback to showing just the error type
+	if len(errorMsg) > 150 {
+		return errorMsg[:150] + "..."
+	}
+	return errorMsg
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/graph"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+var graphFormat string
+
+// Show which resources reference which
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show how assistants, tools, workflows, squads and phone numbers reference each other",
+	Long: `Build the reference graph of the account: which assistants use which tools,
+which phone numbers route to which assistant, squad or workflow, which squads
+contain which assistants, and so on.
+
+Any field holding the ID of another resource in the account counts as a
+reference, so the graph also covers transfer destinations and workflow nodes.
+
+Formats:
+  text  Each resource followed by what it references (default)
+  json  Nodes and edges for scripting
+  dot   Graphviz, e.g. vapi graph --format dot | dot -Tsvg > graph.svg
+
+The delete commands use the same graph to refuse deleting a resource that
+is still referenced.`,
+	Example: `  vapi graph
+  vapi graph --format json
+  vapi graph --format dot | dot -Tpng > vapi.png`,
+	Args: cobra.NoArgs,
+	RunE: analytics.TrackCommandWrapper("graph", "show", func(cmd *cobra.Command, args []string) error {
+		fmt.Fprintln(os.Stderr, "🕸️  Building reference graph...")
+		g, err := graph.Build(cmd.Context(), vapiClient)
+		if err != nil {
+			return err
+		}
+
+		switch graphFormat {
+		case "dot":
+			g.WriteDOT(os.Stdout)
+		case "json":
+			err = output.PrintJSON(g)
+		case "", "text":
+			if output.IsStructured() || output.HasSelector() {
+				err = output.Render(g, graphTable(g))
+			} else if len(g.Edges) == 0 {
+				fmt.Printf("No references between the %d resource(s) in this account.\n", len(g.Nodes))
+			} else {
+				g.WriteText(os.Stdout)
+			}
+		default:
+			return fmt.Errorf("unknown --format %q (valid: text, json, dot)", graphFormat)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to display graph: %w", err)
+		}
+		return nil
+	}),
+}
+
+func graphTable(g *graph.Graph) *output.Table {
+	table := &output.Table{Headers: []string{"FROM", "FIELD", "TO"}}
+	for _, e := range g.Edges {
+		from, _ := g.Node(e.From)
+		to, _ := g.Node(e.To)
+		table.Rows = append(table.Rows, []string{from.Label(), e.Field, to.Label()})
+	}
+	return table
+}
+
+// checkDependents refuses to delete a resource other resources still
+// reference, listing them. With force the dependents are only reported.
+func checkDependents(ctx context.Context, kind, id string, force bool) error {
+	g, err := graph.Build(ctx, vapiClient)
+	if err != nil {
+		if force {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not check what references this %s: %v\n", kind, err)
+			return nil
+		}
+		return fmt.Errorf("failed to check what references %s %s (use --force to delete anyway): %w", kind, id, err)
+	}
+
+	dependents := g.Dependents(id)
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, e := range dependents {
+		from, _ := g.Node(e.From)
+		fmt.Fprintf(&b, "  %s  via %s\n", from.Label(), e.Field)
+	}
+	if force {
+		fmt.Fprintf(os.Stderr, "⚠️  Deleting %s %s, which is still referenced by:\n%s", kind, id, b.String())
+		return nil
+	}
+	return fmt.Errorf("%s %s is still referenced by:\n%sRemove these references first, or use --force to delete it anyway", kind, id, b.String())
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVar(&graphFormat, "format", "text", "Graph format: text, json or dot")
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/diff"
+	"github.com/VapiAI/cli/pkg/output"
+)
+
+// newHistoryCmd lists the local revisions of a resource of kind
+func newHistoryCmd(parent string, kind client.ResourceKind) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("history [%s-id|name]", parent),
+		Short: fmt.Sprintf("List saved %s revisions", kind.Name),
+		Long: fmt.Sprintf(`List the revisions saved on this machine each time the %s was updated
+by the CLI (update, edit, apply, import and rollback).
+
+Revisions are stored per account under the user config directory. Restore
+one with 'vapi %s rollback <id> --to <revision>'.`, kind.Name, parent),
+		Args: cobra.ExactArgs(1),
+		RunE: analytics.TrackCommandWrapper(parent, "history", func(cmd *cobra.Command, args []string) error {
+			id, err := vapiClient.Resolve(cmd.Context(), kind, args[0])
+			if err != nil {
+				return err
+			}
+
+			revisions, err := vapiClient.History(kind, id)
+			if err != nil {
+				return err
+			}
+			if len(revisions) == 0 {
+				fmt.Fprintf(os.Stderr, "No saved revisions for %s %s. Revisions are saved when the CLI updates it.\n", kind.Name, id)
+				return nil
+			}
+
+			// Newest first
+			table := &output.Table{Headers: []string{"REVISION", "SAVED", "NAME", "LAST UPDATED"}}
+			rows := make([]client.Revision, 0, len(revisions))
+			for i := len(revisions) - 1; i >= 0; i-- {
+				rev := revisions[i]
+				rows = append(rows, rev)
+				table.Rows = append(table.Rows, []string{
+					strconv.Itoa(rev.Number),
+					rev.SavedAt.Local().Format("2006-01-02 15:04:05"),
+					kind.NameOf(rev.Data),
+					rawTime(rev.Data, "updatedAt", "2006-01-02 15:04:05"),
+				})
+			}
+			return output.Render(rows, table)
+		}),
+	}
+}
+
+// newRollbackCmd restores a saved revision of a resource of kind
+func newRollbackCmd(parent string, kind client.ResourceKind) *cobra.Command {
+	var to int
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("rollback [%s-id|name] --to <revision>", parent),
+		Short: fmt.Sprintf("Roll back to a saved %s revision", kind.Name),
+		Long: fmt.Sprintf(`Restore the %s to a revision listed by 'vapi %s history'.
+
+The difference between the live %s and the revision is shown before
+anything changes. Fields added since the revision are cleared. The state
+being replaced is saved as a new revision, so a rollback can be undone.`, kind.Name, parent, kind.Name),
+		Example: fmt.Sprintf(`  vapi %s history support
+  vapi %s rollback support --to 3`, parent, parent),
+		Args: cobra.ExactArgs(1),
+		RunE: analytics.TrackCommandWrapper(parent, "rollback", func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if to <= 0 {
+				return fmt.Errorf("choose a revision with --to (see 'vapi %s history %s')", parent, args[0])
+			}
+
+			id, err := vapiClient.Resolve(ctx, kind, args[0])
+			if err != nil {
+				return err
+			}
+			rev, err := vapiClient.Revision(kind, id, to)
+			if err != nil {
+				return err
+			}
+			live, err := vapiClient.FetchRaw(ctx, kind, id)
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %w", kind.Name, err)
+			}
+
+			current := kind.Editable(live)
+			target := kind.Editable(rev.Data)
+			patch := minimalPatch(current, target)
+			if len(patch) == 0 {
+				fmt.Fprintf(os.Stderr, "%s %s already matches revision %d.\n", kind.Name, id, to)
+				return nil
+			}
+
+			currentDoc, err := diff.Document(current)
+			if err != nil {
+				return err
+			}
+			targetDoc, err := diff.Document(target)
+			if err != nil {
+				return err
+			}
+			diff.Unified(os.Stdout, "live", fmt.Sprintf("revision %d", to), currentDoc, targetDoc)
+
+			if !yes {
+				var confirm bool
+				prompt := &survey.Confirm{
+					Message: fmt.Sprintf("Roll back %s %s to revision %d?", kind.Name, id, to),
+					Default: false,
+				}
+				if err := survey.AskOne(prompt, &confirm); err != nil {
+					return fmt.Errorf("rollback canceled: %w", err)
+				}
+				if !confirm {
+					fmt.Fprintln(os.Stderr, "Rollback canceled.")
+					return nil
+				}
+			}
+
+			if _, err := vapiClient.UpdateRaw(ctx, kind, id, patch); err != nil {
+				return fmt.Errorf("failed to roll back %s: %w", kind.Name, err)
+			}
+			fmt.Fprintf(os.Stderr, "✅ Rolled back %s %s to revision %d\n", kind.Name, id, to)
+			analytics.TrackEvent(parent+"_rollback_success", map[string]interface{}{
+				"fields": len(patch),
+			})
+			return nil
+		}),
+	}
+
+	cmd.Flags().IntVar(&to, "to", 0, "Revision to restore")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	return cmd
+}
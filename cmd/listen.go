@@ -28,11 +28,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -69,7 +66,7 @@ Examples:
 			return fmt.Errorf("invalid --forward-to URL: %w", err)
 		}
 
-		return startWebhookListener(forwardURL, listenPort, skipVerify)
+		return startWebhookListener(cmd.Context(), forwardURL, listenPort, skipVerify)
 	},
 }
 
@@ -93,7 +90,7 @@ func normalizeURL(rawURL string) (string, error) {
 }
 
 // startWebhookListener starts the local webhook server and forwarding logic
-func startWebhookListener(forwardURL string, port int, skipVerify bool) error {
+func startWebhookListener(ctx context.Context, forwardURL string, port int, skipVerify bool) error {
 	// Create styles for better output formatting
 	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true)
 	infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF"))
@@ -127,10 +124,6 @@ func startWebhookListener(forwardURL string, port int, skipVerify bool) error {
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
-	// Handle graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	// Start server in a goroutine
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -138,16 +131,13 @@ func startWebhookListener(forwardURL string, port int, skipVerify bool) error {
 		}
 	}()
 
-	// Wait for interrupt signal
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	<-c
+	// The root context is cancelled on SIGINT/SIGTERM
+	<-ctx.Done()
 	fmt.Println()
 	fmt.Println(infoStyle.Render("Shutting down webhook listener..."))
 
 	// Shutdown server with timeout
-	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 5*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 	defer shutdownCancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
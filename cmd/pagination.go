@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// defaultListLimit matches the page size list commands have always shown
+const defaultListLimit = 50
+
+// addListFlags registers the shared pagination flags on a list command
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("limit", defaultListLimit, "Maximum number of items to return")
+	cmd.Flags().Bool("all", false, "Return every item, paging through the full history")
+	cmd.Flags().Int("page-size", client.DefaultPageSize, "Number of items to request per API call")
+	cmd.Flags().String("created-after", "", "Only include items created after this time (RFC3339, YYYY-MM-DD or relative like 24h, 7d)")
+	cmd.Flags().String("created-before", "", "Only include items created before this time (RFC3339, YYYY-MM-DD or relative like 24h, 7d)")
+}
+
+// listOptionsFromFlags reads the pagination flags added by addListFlags
+func listOptionsFromFlags(cmd *cobra.Command) (client.ListOptions, error) {
+	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	after, _ := cmd.Flags().GetString("created-after")
+	before, _ := cmd.Flags().GetString("created-before")
+
+	if all && cmd.Flags().Changed("limit") {
+		return client.ListOptions{}, fmt.Errorf("--all and --limit cannot be used together")
+	}
+	if limit <= 0 && !all {
+		return client.ListOptions{}, fmt.Errorf("--limit must be greater than 0 (use --all for no limit)")
+	}
+	if pageSize <= 0 {
+		return client.ListOptions{}, fmt.Errorf("--page-size must be greater than 0")
+	}
+
+	opts := client.ListOptions{
+		Limit:    limit,
+		PageSize: pageSize,
+	}
+	if all {
+		opts.Limit = 0
+	}
+
+	var err error
+	if opts.CreatedAfter, err = parseTimeFlag(after); err != nil {
+		return client.ListOptions{}, fmt.Errorf("invalid --created-after: %w", err)
+	}
+	if opts.CreatedBefore, err = parseTimeFlag(before); err != nil {
+		return client.ListOptions{}, fmt.Errorf("invalid --created-before: %w", err)
+	}
+	if opts.CreatedAfter != nil && opts.CreatedBefore != nil && !opts.CreatedAfter.Before(*opts.CreatedBefore) {
+		return client.ListOptions{}, fmt.Errorf("--created-after must be earlier than --created-before")
+	}
+
+	return opts, nil
+}
+
+// parseTimeFlag accepts an absolute timestamp or a duration relative to now
+// such as "90m", "24h" or "7d". An empty value returns nil.
+func parseTimeFlag(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t, nil
+		}
+	}
+
+	// Days are not understood by time.ParseDuration
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err == nil && days >= 0 {
+			t := time.Now().AddDate(0, 0, -days)
+			return &t, nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := time.Now().Add(-d)
+		return &t, nil
+	}
+
+	return nil, fmt.Errorf("%q is not a timestamp or relative duration", value)
+}
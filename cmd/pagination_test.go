@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	empty, err := parseTimeFlag("")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	abs, err := parseTimeFlag("2025-03-04T05:06:07Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC), abs.UTC())
+
+	day, err := parseTimeFlag("2025-03-04")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), *day)
+
+	relative, err := parseTimeFlag("24h")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), *relative, time.Minute)
+
+	days, err := parseTimeFlag("7d")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), *days, time.Minute)
+
+	_, err = parseTimeFlag("yesterday")
+	assert.Error(t, err)
+}
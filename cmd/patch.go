@@ -0,0 +1,191 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+	"github.com/VapiAI/cli/pkg/patch"
+)
+
+// patchFlags are the field-level alternatives to a whole --json/--file
+// payload
+var patchFlags = []string{"set", "set-file", "unset", "append"}
+
+// patchHelp is appended to the long help of every update command
+const patchHelp = `
+Change single fields with dotted paths instead of a whole document:
+  --set model.temperature=0.3            Numbers, true/false, null and JSON are typed
+  --set-file model.messages[0].content=prompt.md
+  --unset voice.speed
+  --append model.toolIds=<tool-id>
+Field flags are applied to the current configuration in that order and only
+the top-level fields that change are sent. Use --dry-run to print the PATCH
+body without sending it.`
+
+// addUpdateFlags registers the payload and field flags read by
+// updateFromPayload. noun names the resource in the help text.
+func addUpdateFlags(cmd *cobra.Command, noun string) {
+	cmd.Flags().String("json", "", "Raw JSON payload string or '-' to read from stdin")
+	cmd.Flags().String("file", "", fmt.Sprintf("Path to JSON file with %s payload", noun))
+	cmd.Flags().StringArray("set", nil, "Set a field (path=value, repeatable)")
+	cmd.Flags().StringArray("set-file", nil, "Set a field to the contents of a file (path=file, repeatable)")
+	cmd.Flags().StringArray("unset", nil, "Remove a field (path, repeatable)")
+	cmd.Flags().StringArray("append", nil, "Append a value to an array field (path=value, repeatable)")
+	cmd.Flags().Bool("dry-run", false, "Print the PATCH body without sending it")
+	cmd.Flags().Bool("no-validate", false, "Send the payload without checking it against the local schema")
+	cmd.Long += "\n" + patchHelp
+}
+
+// hasUpdateFlags reports whether any payload or field flag was given
+func hasUpdateFlags(cmd *cobra.Command) bool {
+	for _, name := range append([]string{"json", "file"}, patchFlags...) {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// patchOpsFromFlags reads --set, --set-file, --unset and --append
+func patchOpsFromFlags(cmd *cobra.Command) ([]patch.Op, error) {
+	flags := cmd.Flags()
+	var ops []patch.Op
+
+	sets, _ := flags.GetStringArray("set")
+	for _, set := range sets {
+		path, raw, err := splitKeyValue(set)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --set: %w", err)
+		}
+		ops = append(ops, patch.Op{Kind: patch.Set, Path: path, Value: patch.ParseValue(raw)})
+	}
+
+	setFiles, _ := flags.GetStringArray("set-file")
+	for _, set := range setFiles {
+		path, file, err := splitKeyValue(set)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --set-file: %w", err)
+		}
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --set-file: %w", err)
+		}
+		ops = append(ops, patch.Op{Kind: patch.Set, Path: path, Value: string(data)})
+	}
+
+	unsets, _ := flags.GetStringArray("unset")
+	for _, path := range unsets {
+		ops = append(ops, patch.Op{Kind: patch.Unset, Path: path})
+	}
+
+	appends, _ := flags.GetStringArray("append")
+	for _, item := range appends {
+		path, raw, err := splitKeyValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --append: %w", err)
+		}
+		ops = append(ops, patch.Op{Kind: patch.Append, Path: path, Value: patch.ParseValue(raw)})
+	}
+	return ops, nil
+}
+
+// updateFromPayload patches the resource ref with the payload given by
+// --json or --file and the field flags. The previous state is recorded in
+// the local history. Nothing is returned when nothing was sent, either with
+// --dry-run or because the field flags change nothing.
+func updateFromPayload(cmd *cobra.Command, kind client.ResourceKind, ref string) (map[string]interface{}, error) {
+	jsonStr, _ := cmd.Flags().GetString("json")
+	filePath, _ := cmd.Flags().GetString("file")
+	ops, err := patchOpsFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if jsonStr == "" && filePath == "" && len(ops) == 0 {
+		return nil, fmt.Errorf("provide --json, --file or --set/--set-file/--unset/--append for the update")
+	}
+
+	var payload map[string]interface{}
+	var source []byte
+	if jsonStr != "" || filePath != "" {
+		if source, err = readJSONPayload(jsonStr, filePath); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(source, &payload); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	ctx := cmd.Context()
+	id, err := vapiClient.Resolve(ctx, kind, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Field flags edit the live configuration, so nested objects keep their
+	// other settings when the changed top-level fields are sent back
+	if len(ops) > 0 {
+		live, err := vapiClient.FetchRaw(ctx, kind, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", kind.Name, err)
+		}
+		current := kind.Editable(live)
+		merged := kind.Editable(live)
+		for key, value := range payload {
+			merged[key] = value
+		}
+		updated, err := patch.Apply(merged, ops)
+		if err != nil {
+			return nil, err
+		}
+		payload = minimalPatch(current, updated)
+		if len(payload) == 0 {
+			fmt.Fprintf(os.Stderr, "No changes: %s %s already has these values.\n", kind.Name, id)
+			return nil, nil
+		}
+	}
+
+	// Line numbers only hold for the --json or --file input as written
+	if len(ops) > 0 {
+		err = validatePatch(cmd, kind, payload, ops)
+	} else {
+		err = validatePayload(cmd, kind, true, payload, source, payloadSourceName(cmd))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Fprintf(os.Stderr, "Dry run: PATCH %s/%s would send:\n", kind.Path, id)
+		return nil, output.Render(payload, nil)
+	}
+
+	updated, err := vapiClient.UpdateRaw(ctx, kind, id, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", kind.Name, err)
+	}
+	return updated, nil
+}
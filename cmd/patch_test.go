@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/patch"
+)
+
+func TestPatchOpsFromFlags(t *testing.T) {
+	prompt := filepath.Join(t.TempDir(), "prompt.md")
+	require.NoError(t, os.WriteFile(prompt, []byte("You are helpful.\n"), 0o600))
+
+	cmd := &cobra.Command{Use: "update"}
+	addUpdateFlags(cmd, "assistant")
+	assert.False(t, hasUpdateFlags(cmd))
+	require.NoError(t, cmd.ParseFlags([]string{
+		"--append", "model.toolIds=t2",
+		"--set", "model.temperature=0.3",
+		"--unset", "voice.speed",
+		"--set-file", "model.messages[0].content=" + prompt,
+	}))
+	assert.True(t, hasUpdateFlags(cmd))
+
+	ops, err := patchOpsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []patch.Op{
+		{Kind: patch.Set, Path: "model.temperature", Value: 0.3},
+		{Kind: patch.Set, Path: "model.messages[0].content", Value: "You are helpful.\n"},
+		{Kind: patch.Unset, Path: "voice.speed"},
+		{Kind: patch.Append, Path: "model.toolIds", Value: "t2"},
+	}, ops)
+
+	cmd = &cobra.Command{Use: "update"}
+	addUpdateFlags(cmd, "assistant")
+	require.NoError(t, cmd.ParseFlags([]string{"--set", "model.temperature"}))
+	_, err = patchOpsFromFlags(cmd)
+	assert.ErrorContains(t, err, "invalid --set")
+}
+
+func TestSetToCurrentValueIsNoChange(t *testing.T) {
+	var live map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"model": {"temperature": 0.7, "maxTokens": 250}}`), &live))
+
+	updated, err := patch.Apply(live, []patch.Op{
+		{Kind: patch.Set, Path: "model.temperature", Value: patch.ParseValue("0.7")},
+		{Kind: patch.Set, Path: "model.maxTokens", Value: patch.ParseValue("250")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, minimalPatch(live, updated))
+}
+
+func TestValidatePatchNamesFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "update"}
+	addUpdateFlags(cmd, "assistant")
+	ops := []patch.Op{{Kind: patch.Set, Path: "model.temperature", Value: "hot"}}
+	payload := map[string]interface{}{"model": map[string]interface{}{"provider": "openai", "model": "gpt-4o", "temperature": "hot"}}
+
+	err := validatePatch(cmd, client.Assistants, payload, ops)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the assistant update is not a valid payload")
+	assert.Contains(t, err.Error(), "/model/temperature: ")
+	assert.Contains(t, err.Error(), "(from --set model.temperature)")
+	assert.NotContains(t, err.Error(), "update:1:")
+}
+
+func TestPathPointer(t *testing.T) {
+	assert.Equal(t, "/model/messages/0/content", pathPointer("model.messages[0].content"))
+	assert.Equal(t, "/a/1/2", pathPointer("a[1][2]"))
+	assert.Equal(t, "/metadata/a~1b", pathPointer("metadata.a/b"))
+}
@@ -19,13 +19,13 @@ Authors:
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"os"
 
 	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/output"
 )
 
@@ -47,71 +47,88 @@ var listPhoneCmd = &cobra.Command{
 	Short: "List all phone numbers",
 	Long:  `Display all phone numbers in your account with their status and configuration.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("📞 Listing phone numbers...")
+		fmt.Fprintln(os.Stderr, "📞 Listing phone numbers...")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Fetch phone numbers from the API
-		phoneNumbers, err := vapiClient.GetClient().PhoneNumbers.List(ctx, nil)
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			// Check if this is a deserialization error related to new features
-			if strings.Contains(err.Error(), "cannot be deserialized") {
-				fmt.Println("⚠️  Warning: The Vapi API returned data in a format not yet supported by this CLI version.")
-				fmt.Println("   This usually happens when new features are added to Vapi.")
-				fmt.Println("   Please check for CLI updates: https://github.com/VapiAI/cli/releases")
-				fmt.Println()
-				fmt.Printf("   Technical details: %v\n", err)
-				return fmt.Errorf("incompatible API response format")
-			}
-			return fmt.Errorf("failed to list phone numbers: %w", err)
-		}
-
-		if len(phoneNumbers) == 0 {
-			fmt.Println("No phone numbers found. Create one with 'vapi phone create'")
-			return nil
+			return err
 		}
 
-		// Display in a readable table format
-		fmt.Printf("\nFound %d phone number(s):\n\n", len(phoneNumbers))
-		fmt.Printf("%-36s %-16s %-25s %-8s %-20s\n", "ID", "Number", "Name", "Status", "Created")
-		fmt.Printf("%-36s %-16s %-25s %-8s %-20s\n", "----", "------", "----", "------", "-------")
+		it := vapiClient.ListPhoneNumbers(opts)
+		stream := output.NewStream([]string{"ID", "NUMBER", "NAME", "STATUS", "ASSISTANT ID", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			phoneNumber := it.Item()
 
-		for _, phoneNumber := range phoneNumbers {
 			// Extract common fields from the union type
 			fields := extractPhoneNumberFields(*phoneNumber)
 
-			// Show assistant ID in a separate line if it exists
-			fmt.Printf("%-36s %-16s %-25s %-8s %-20s\n",
-				fields.ID, fields.Number, fields.Name, fields.Status, fields.CreatedAt)
-
-			if fields.AssistantID != "None" {
-				fmt.Printf("  └─ Assistant: %s\n", fields.AssistantID)
+			row := []string{fields.ID, fields.Number, fields.Name, fields.Status, fields.AssistantID, fields.CreatedAt}
+			if err := stream.Add(phoneNumber, row); err != nil {
+				return fmt.Errorf("failed to display phone numbers: %w", err)
 			}
 		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawPages("/phone-number", nil), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					rawField(item, "number", rawField(item, "sipUri", "")),
+					rawField(item, "name", ""),
+					rawField(item, "status", ""),
+					rawField(item, "assistantId", ""),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list phone numbers: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list phone numbers: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display phone numbers: %w", err)
+		}
 
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No phone numbers found. Create one with 'vapi phone create'")
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "\nFound %d phone number(s)\n", count)
 		return nil
 	},
 }
 
 var getPhoneCmd = &cobra.Command{
-	Use:   "get [phone-number-id]",
+	Use:   "get [phone-number-id|name|number]",
 	Short: "Get details of a specific phone number",
 	Long:  `Retrieve the complete configuration of a phone number including routing and settings.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		phoneNumberID := args[0]
+		ctx := cmd.Context()
+		phoneNumberID, err := vapiClient.Resolve(ctx, client.PhoneNumbers, args[0])
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("🔍 Getting phone number details for ID: %s\n", phoneNumberID)
+		fmt.Fprintf(os.Stderr, "🔍 Getting phone number details for ID: %s\n", phoneNumberID)
 
 		// Fetch the phone number configuration
 		phoneNumber, err := vapiClient.GetClient().PhoneNumbers.Get(ctx, phoneNumberID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/phone-number/"+phoneNumberID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get phone number: %w", err)
 		}
 
-		// Display as formatted JSON for easy reading
-		if err := output.PrintJSON(phoneNumber); err != nil {
+		// Display in the selected format (JSON by default)
+		if err := output.Render(phoneNumber, nil); err != nil {
 			return fmt.Errorf("failed to display phone number: %w", err)
 		}
 
@@ -145,40 +162,50 @@ which is best done through the Vapi dashboard interface.`,
 }
 
 var updatePhoneCmd = &cobra.Command{
-	Use:   "update [phone-number-id]",
+	Use:   "update [phone-number-id|name|number]",
 	Short: "Update phone number configuration",
 	Long: `Update the configuration of an existing phone number.
 	
-This includes routing settings, webhooks, and other phone number parameters.`,
+This includes routing settings, webhooks, and other phone number parameters.
+
+Provide the fields to change as JSON via --json or --file. The previous
+configuration is saved locally first.`,
+	Example: `  vapi phone update +14155551234 --set assistantId=<assistant-id>
+  vapi phone update support-line --set server.url=https://example.com/hook --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		phoneNumberID := args[0]
-
-		fmt.Printf("📝 Update phone number: %s\n", phoneNumberID)
-		fmt.Println()
-		fmt.Println("Phone number updates can include:")
-		fmt.Println("- Inbound call routing")
-		fmt.Println("- Webhook configurations")
-		fmt.Println("- Assistant assignments")
-		fmt.Println("- Call forwarding settings")
-		fmt.Println()
-		fmt.Println("Update via the Vapi dashboard:")
-		fmt.Printf("https://dashboard.vapi.ai/phone-numbers/%s\n", phoneNumberID)
-		fmt.Println()
-		fmt.Println("Or use the Vapi API: PATCH /phone-number/{id}")
+		phoneNumber, err := updateFromPayload(cmd, client.PhoneNumbers, args[0])
+		if err != nil || phoneNumber == nil {
+			return err
+		}
 
+		fmt.Fprintln(os.Stderr, "✅ Phone number updated successfully")
+		if err := output.Render(phoneNumber, nil); err != nil {
+			return fmt.Errorf("failed to display phone number: %w", err)
+		}
 		return nil
 	},
 }
 
 var deletePhoneCmd = &cobra.Command{
-	Use:   "delete [phone-number-id]",
+	Use:   "delete [phone-number-id|name|number]",
 	Short: "Release a phone number",
-	Long:  `Release a phone number from your account. This will stop billing and make the number unavailable.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Release a phone number from your account. This will stop billing and make the number unavailable.
+
+Releasing is refused while other resources, such as transfer tools, still
+reference the number. Use --force to release it anyway.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		phoneNumberID := args[0]
+		ctx := cmd.Context()
+		phoneNumberID, err := vapiClient.Resolve(ctx, client.PhoneNumbers, args[0])
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkDependents(ctx, "phone number", phoneNumberID, force); err != nil {
+			return err
+		}
 
 		// Require explicit confirmation for destructive actions
 		confirmed, err := confirmDeletion("phone number", phoneNumberID)
@@ -189,16 +216,17 @@ var deletePhoneCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("🗑️  Releasing phone number with ID: %s\n", phoneNumberID)
+		fmt.Fprintf(os.Stderr, "🗑️  Releasing phone number with ID: %s\n", phoneNumberID)
 
 		// Execute deletion via API
 		_, err = vapiClient.GetClient().PhoneNumbers.Delete(ctx, phoneNumberID)
 		if err != nil {
 			return fmt.Errorf("failed to release phone number: %w", err)
 		}
+		vapiClient.ForgetNames(client.PhoneNumbers)
 
-		fmt.Println("✅ Phone number released successfully")
-		fmt.Println("Note: Billing for this number will stop within 24 hours")
+		fmt.Fprintln(os.Stderr, "✅ Phone number released successfully")
+		fmt.Fprintln(os.Stderr, "Note: Billing for this number will stop within 24 hours")
 		return nil
 	},
 }
@@ -321,4 +349,10 @@ func init() {
 	phoneCmd.AddCommand(createPhoneCmd)
 	phoneCmd.AddCommand(updatePhoneCmd)
 	phoneCmd.AddCommand(deletePhoneCmd)
+
+	addListFlags(listPhoneCmd)
+
+	addUpdateFlags(updatePhoneCmd, "phone number")
+
+	deletePhoneCmd.Flags().Bool("force", false, "Release even if other resources still reference it")
 }
@@ -19,8 +19,15 @@ Authors:
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -30,12 +37,14 @@ import (
 	"github.com/VapiAI/cli/pkg/analytics"
 	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/config"
+	"github.com/VapiAI/cli/pkg/output"
 )
 
 var (
 	cfgFile     string
 	vapiClient  *client.VapiClient
 	bannerShown bool
+	debugFile   *os.File
 )
 
 // ASCII art banner
@@ -137,13 +146,26 @@ func init() {
 
 	// Set up PersistentPreRunE here to avoid initialization cycle
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// Resolve the output format before anything can print
+		format, err := output.ParseFormat(viper.GetString("output"))
+		if err != nil {
+			return err
+		}
+		output.SetFormat(format)
+		output.SetQuery(viper.GetString("query"))
+		output.SetTemplate(viper.GetString("template"))
+
+		if err := setupDebugLogging(); err != nil {
+			return err
+		}
+
 		// Skip validation for root command with no subcommands (just showing help)
 		if cmd.Parent() == nil && len(args) == 0 && len(cmd.Commands()) > 0 {
 			return nil
 		}
 
 		// Skip API key validation for commands that don't need it
-		skipAuthCommands := []string{"login", "config", "init", "completion", "help", "version", "update", "mcp", "auth", "manual"}
+		skipAuthCommands := []string{"login", "config", "init", "completion", "help", "version", "update", "mcp", "auth", "manual", "validate"}
 		for _, skipCmd := range skipAuthCommands {
 			if cmd.Name() == skipCmd || (cmd.Parent() != nil && cmd.Parent().Name() == skipCmd) {
 				return nil
@@ -158,7 +180,6 @@ func init() {
 		}
 
 		// Initialize the Vapi client for API commands
-		var err error
 		vapiClient, err = client.NewVapiClient(apiKey)
 		if err != nil {
 			return fmt.Errorf("failed to initialize Vapi client: %w", err)
@@ -183,18 +204,82 @@ func init() {
 		fmt.Printf("Warning: failed to bind api-key flag: %v\n", err)
 	}
 
+	// Global flag for output format
+	rootCmd.PersistentFlags().StringP("output", "o", "", "Output format: table, json, yaml or csv (default table for lists, json for details)")
+	if err := viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output")); err != nil {
+		fmt.Printf("Warning: failed to bind output flag: %v\n", err)
+	}
+
+	// Global flags for tracing HTTP traffic
+	rootCmd.PersistentFlags().Bool("debug", false, "Log every API request and response to stderr with secrets redacted")
+	rootCmd.PersistentFlags().String("debug-file", "", "Append redacted API requests and responses to this file as JSON lines")
+	if err := viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug")); err != nil {
+		fmt.Printf("Warning: failed to bind debug flag: %v\n", err)
+	}
+	if err := viper.BindPFlag("debug_file", rootCmd.PersistentFlags().Lookup("debug-file")); err != nil {
+		fmt.Printf("Warning: failed to bind debug-file flag: %v\n", err)
+	}
+
+	// Global flag bounding each API request
+	rootCmd.PersistentFlags().Int("timeout", 30, "Seconds to wait for each API request before giving up, 0 to wait forever")
+	if err := viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
+		fmt.Printf("Warning: failed to bind timeout flag: %v\n", err)
+	}
+
+	// Global flags for retrying rate-limited and failed API requests
+	rootCmd.PersistentFlags().Int("max-retries", 3, "Retries for rate-limited (429) or transient (5xx) API errors, 0 to disable")
+	rootCmd.PersistentFlags().Bool("retry-all-methods", false, "Also retry non-idempotent requests (POST, PATCH) on transient errors")
+	if err := viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries")); err != nil {
+		fmt.Printf("Warning: failed to bind max-retries flag: %v\n", err)
+	}
+	if err := viper.BindPFlag("retry_all_methods", rootCmd.PersistentFlags().Lookup("retry-all-methods")); err != nil {
+		fmt.Printf("Warning: failed to bind retry-all-methods flag: %v\n", err)
+	}
+
+	// Global flags for selecting fields from command output
+	rootCmd.PersistentFlags().String("query", "", "JSONPath-style field selector applied to output (e.g. '.artifact.recordingUrl')")
+	rootCmd.PersistentFlags().String("template", "", "Go text/template used to render output (e.g. '{{range .}}{{.Id}}{{\"\\n\"}}{{end}}')")
+	for _, name := range []string{"query", "template"} {
+		if err := viper.BindPFlag(name, rootCmd.PersistentFlags().Lookup(name)); err != nil {
+			fmt.Printf("Warning: failed to bind %s flag: %v\n", name, err)
+		}
+	}
+
 	// Add version flag
 	rootCmd.Flags().BoolP("version", "v", false, "Print version information")
 }
 
 // Execute runs the root command - this is the main entry point
 func Execute() {
+	// One root context for every command, cancelled on Ctrl+C or SIGTERM so
+	// in-flight API requests are aborted instead of left hanging
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
 	// Execute the CLI
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+	closeDebugFile()
+
+	var exit *exitError
+	if errors.As(err, &exit) {
+		analytics.Close()
+		os.Exit(exit.code)
+	}
+
+	if err != nil {
 		analytics.TrackError(err.Error(), map[string]interface{}{
 			"command": "root",
 		})
 		analytics.Close()
+
+		if interrupted {
+			fmt.Fprintln(os.Stderr, "Interrupted")
+			os.Exit(130)
+		}
+		if isTimeout(err) {
+			fmt.Fprintf(os.Stderr, "The API did not respond within %ds. Raise the limit with --timeout or 'vapi config set timeout <seconds>'.\n", viper.GetInt("timeout"))
+		}
 		os.Exit(1)
 	}
 
@@ -202,6 +287,23 @@ func Execute() {
 	analytics.Close()
 }
 
+// exitError ends the CLI with a status code but no error message, for
+// commands whose exit status is their result (such as 'diff --exit-code')
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// silentExit makes cmd exit with code without printing an error or usage
+func silentExit(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitError{code: code}
+}
+
 // Initialize viper configuration from file and environment
 func initConfig() {
 	if cfgFile != "" {
@@ -223,7 +325,7 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		// Config file was found and read successfully
 		if viper.GetBool("debug") {
-			fmt.Printf("Using config file: %s\n", viper.ConfigFileUsed())
+			fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
 		}
 	}
 
@@ -232,7 +334,7 @@ func initConfig() {
 	if err != nil {
 		// Don't fail the CLI if config loading fails
 		if viper.GetBool("debug") {
-			fmt.Printf("Warning: failed to load config: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
 		}
 	} else {
 		config.SetConfig(cfg)
@@ -254,3 +356,42 @@ func printAuthPrompt() {
 	fmt.Println("  export VAPI_API_KEY=your_api_key_here")
 	fmt.Println()
 }
+
+// setupDebugLogging installs the HTTP trace logger for --debug and --debug-file
+func setupDebugLogging() error {
+	var console io.Writer
+	if viper.GetBool("debug") {
+		console = os.Stderr
+	}
+
+	var file io.Writer
+	if path := viper.GetString("debug_file"); path != "" && debugFile == nil {
+		f, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open debug file: %w", err)
+		}
+		debugFile = f
+		file = f
+	}
+
+	if console != nil || file != nil {
+		client.SetDebugLogger(client.NewDebugLogger(console, file))
+	}
+	return nil
+}
+
+func closeDebugFile() {
+	if debugFile != nil {
+		_ = debugFile.Close()
+		debugFile = nil
+	}
+}
+
+// isTimeout reports whether err was caused by a request exceeding --timeout
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
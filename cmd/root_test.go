@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -126,3 +130,28 @@ func TestNoAuthValidation(t *testing.T) {
 		})
 	}
 }
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "deadline exceeded", err: fmt.Errorf("failed to list calls: %w", context.DeadlineExceeded), expected: true},
+		{name: "client timeout", err: &url.Error{Op: "Get", URL: "https://api.vapi.ai/call", Err: timeoutError{}}, expected: true},
+		{name: "cancelled", err: context.Canceled, expected: false},
+		{name: "api error", err: errors.New("API error 404: not found"), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTimeout(tt.err))
+		})
+	}
+}
@@ -19,13 +19,13 @@ Authors:
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"os"
 
 	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/output"
 )
 
@@ -48,67 +48,85 @@ var listToolCmd = &cobra.Command{
 	Short: "List all tools",
 	Long:  `Display all custom tools and functions in your account with their configurations.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("🔧 Listing tools...")
+		fmt.Fprintln(os.Stderr, "🔧 Listing tools...")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		// Fetch tools from the API
-		// Note: The exact API endpoint may vary, this follows the pattern of other list commands
-		tools, err := vapiClient.GetClient().Tools.List(ctx, nil)
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			// Check if this is a deserialization error related to new features
-			if strings.Contains(err.Error(), "cannot be deserialized") {
-				fmt.Println("⚠️  Warning: The Vapi API returned data in a format not yet supported by this CLI version.")
-				fmt.Println("   This usually happens when new features are added to Vapi.")
-				fmt.Println("   Please check for CLI updates: https://github.com/VapiAI/cli/releases")
-				fmt.Println()
-				fmt.Printf("   Technical details: %v\n", err)
-				return fmt.Errorf("incompatible API response format")
-			}
-			return fmt.Errorf("failed to list tools: %w", err)
-		}
-
-		if len(tools) == 0 {
-			fmt.Println("No tools found. Create one with 'vapi tool create'")
-			return nil
+			return err
 		}
 
-		// Display in a readable table format
-		fmt.Printf("\nFound %d tool(s):\n\n", len(tools))
-		fmt.Printf("%-36s %-30s %-20s %-20s\n", "ID", "Name", "Type", "Created")
-		fmt.Printf("%-36s %-30s %-20s %-20s\n", "----", "----", "----", "-------")
+		it := vapiClient.ListTools(opts)
+		stream := output.NewStream([]string{"ID", "NAME", "TYPE", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			tool := it.Item()
 
-		for _, tool := range tools {
 			// Extract common fields from the union type
 			id, name, toolType, createdAt := extractToolFields(tool)
 
-			fmt.Printf("%-36s %-30s %-20s %-20s\n",
-				id, name, toolType, createdAt)
+			if err := stream.Add(tool, []string{id, name, toolType, createdAt}); err != nil {
+				return fmt.Errorf("failed to display tools: %w", err)
+			}
+		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawPages("/tool", nil), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					rawField(item, "function.name", "Unknown"),
+					rawField(item, "type", ""),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list tools: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display tools: %w", err)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No tools found. Create one with 'vapi tool create'")
+			return nil
 		}
 
+		fmt.Fprintf(os.Stderr, "\nFound %d tool(s)\n", count)
 		return nil
 	},
 }
 
 var getToolCmd = &cobra.Command{
-	Use:   "get [tool-id]",
+	Use:   "get [tool-id|name]",
 	Short: "Get details of a specific tool",
 	Long:  `Retrieve the complete configuration of a tool including function definition, parameters, and settings.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		toolID := args[0]
+		ctx := cmd.Context()
+		toolID, err := vapiClient.Resolve(ctx, client.Tools, args[0])
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("🔍 Getting tool details for ID: %s\n", toolID)
+		fmt.Fprintf(os.Stderr, "🔍 Getting tool details for ID: %s\n", toolID)
 
 		// Fetch the tool configuration
 		tool, err := vapiClient.GetClient().Tools.Get(ctx, toolID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/tool/"+toolID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get tool: %w", err)
 		}
 
-		// Display as formatted JSON for easy reading
-		if err := output.PrintJSON(tool); err != nil {
+		// Display in the selected format (JSON by default)
+		if err := output.Render(tool, nil); err != nil {
 			return fmt.Errorf("failed to display tool: %w", err)
 		}
 
@@ -157,46 +175,56 @@ This is best done through the Vapi dashboard for visual tool configuration.`,
 }
 
 var updateToolCmd = &cobra.Command{
-	Use:   "update [tool-id]",
+	Use:   "update [tool-id|name]",
 	Short: "Update an existing tool",
 	Long: `Update the configuration of an existing tool.
 	
 This includes modifying function parameters, API endpoints, 
-authentication, and response handling logic.`,
+authentication, and response handling logic.
+
+Provide the fields to change as JSON via --json or --file. The previous
+configuration is saved locally first; see 'vapi tool history'.`,
+	Example: `  vapi tool update lookup-order --file tool.json
+  vapi tool update <tool-id> --json '{"server":{"url":"https://example.com/hook"}}'
+  vapi tool update lookup-order --set server.timeoutSeconds=30`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		toolID := args[0]
-
-		fmt.Printf("📝 Update tool: %s\n", toolID)
-		fmt.Println()
-		fmt.Println("Tool updates can include:")
-		fmt.Println("- Function signature changes")
-		fmt.Println("- API endpoint modifications")
-		fmt.Println("- Authentication updates")
-		fmt.Println("- Parameter validation rules")
-		fmt.Println("- Response data mapping")
-		fmt.Println("- Error handling improvements")
-		fmt.Println()
-		fmt.Println("Update via the Vapi dashboard:")
-		fmt.Printf("https://dashboard.vapi.ai/tools/%s\n", toolID)
-		fmt.Println()
-		fmt.Println("Or use the Vapi API: PATCH /tools/{id}")
+		tool, err := updateFromPayload(cmd, client.Tools, args[0])
+		if err != nil || tool == nil {
+			return err
+		}
 
+		fmt.Fprintln(os.Stderr, "✅ Tool updated successfully")
+		if err := output.Render(tool, nil); err != nil {
+			return fmt.Errorf("failed to display tool: %w", err)
+		}
 		return nil
 	},
 }
 
 var deleteToolCmd = &cobra.Command{
-	Use:   "delete [tool-id]",
+	Use:   "delete [tool-id|name]",
 	Short: "Delete a custom tool",
-	Long:  `Permanently delete a custom tool. This will remove it from all assistants using it.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Permanently delete a custom tool.
+
+Deletion is refused while assistants or other resources still reference the
+tool; the references are listed so they can be removed first. Use --force to
+delete it anyway.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		toolID := args[0]
+		ctx := cmd.Context()
+		toolID, err := vapiClient.Resolve(ctx, client.Tools, args[0])
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkDependents(ctx, "tool", toolID, force); err != nil {
+			return err
+		}
 
 		// Require explicit confirmation for destructive actions
-		confirmed, err := confirmDeletion("tool", fmt.Sprintf("%s (this will remove it from all assistants)", toolID))
+		confirmed, err := confirmDeletion("tool", toolID)
 		if err != nil {
 			return err
 		}
@@ -204,29 +232,34 @@ var deleteToolCmd = &cobra.Command{
 			return nil
 		}
 
-		fmt.Printf("🗑️  Deleting tool with ID: %s\n", toolID)
+		fmt.Fprintf(os.Stderr, "🗑️  Deleting tool with ID: %s\n", toolID)
 
 		// Execute deletion via API
 		_, err = vapiClient.GetClient().Tools.Delete(ctx, toolID)
 		if err != nil {
 			return fmt.Errorf("failed to delete tool: %w", err)
 		}
+		vapiClient.ForgetNames(client.Tools)
 
-		fmt.Println("✅ Tool deleted successfully")
-		fmt.Println("Note: Assistants using this tool may need to be reconfigured")
+		fmt.Fprintln(os.Stderr, "✅ Tool deleted successfully")
+		fmt.Fprintln(os.Stderr, "Note: Assistants using this tool may need to be reconfigured")
 		return nil
 	},
 }
 
 var testToolCmd = &cobra.Command{
-	Use:   "test [tool-id]",
+	Use:   "test [tool-id|name]",
 	Short: "Test a tool with sample input",
 	Long: `Test a tool by calling it with sample input parameters to verify it works correctly.
 	
 This helps debug tool configurations and API integrations before using them in live conversations.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		toolID := args[0]
+		ctx := cmd.Context()
+		toolID, err := vapiClient.Resolve(ctx, client.Tools, args[0])
+		if err != nil {
+			return err
+		}
 
 		fmt.Printf("🧪 Testing tool: %s\n", toolID)
 		fmt.Println()
@@ -567,7 +600,15 @@ func init() {
 	toolCmd.AddCommand(getToolCmd)
 	toolCmd.AddCommand(createToolCmd)
 	toolCmd.AddCommand(updateToolCmd)
+	toolCmd.AddCommand(newHistoryCmd("tool", client.Tools))
+	toolCmd.AddCommand(newRollbackCmd("tool", client.Tools))
 	toolCmd.AddCommand(deleteToolCmd)
 	toolCmd.AddCommand(testToolCmd)
 	toolCmd.AddCommand(listToolTypesCmd)
+
+	addListFlags(listToolCmd)
+
+	addUpdateFlags(updateToolCmd, "tool")
+
+	deleteToolCmd.Flags().Bool("force", false, "Delete even if assistants or other resources still reference it")
 }
@@ -0,0 +1,238 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/VapiAI/cli/pkg/analytics"
+	"github.com/VapiAI/cli/pkg/client"
+	"github.com/VapiAI/cli/pkg/output"
+	"github.com/VapiAI/cli/pkg/patch"
+	"github.com/VapiAI/cli/pkg/schema"
+)
+
+var validateUpdate bool
+
+// Check a payload file offline before using it with create, update or apply
+var validateCmd = &cobra.Command{
+	Use:   "validate <kind> <file>",
+	Short: "Check a JSON or YAML payload against the API schema",
+	Long: `Validate a create or update payload locally, without calling the API.
+
+The schemas are generated from the request types of the Vapi server SDK this
+CLI is built with. Problems are reported with a JSON pointer, the line and
+column in the file, and a suggestion for misspelled or misplaced fields.
+The command exits with status 1 when the payload is invalid.
+
+Create and update commands run the same check on --file and --json payloads
+before sending them. Pass --no-validate there if the API accepts a field
+this CLI version doesn't know yet.
+
+Kinds: ` + validateKindNames(),
+	Example: `  vapi validate assistant assistant.json
+  vapi validate tool lookup-order.yaml --update
+  vapi validate assistant assistant.json --output json`,
+	Args: cobra.ExactArgs(2),
+	RunE: analytics.TrackCommandWrapper("validate", "validate", func(cmd *cobra.Command, args []string) error {
+		kind, err := schema.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		source, err := os.ReadFile(filepath.Clean(args[1]))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[1], err)
+		}
+		doc, err := readDocument(args[1])
+		if err != nil {
+			return err
+		}
+
+		errs := kind.Schema(validateUpdate).Validate(doc)
+		schema.Locate(errs, source)
+
+		if output.IsStructured() || output.HasSelector() {
+			if err := output.Render(errs, validateTable(errs)); err != nil {
+				return fmt.Errorf("failed to display problems: %w", err)
+			}
+		} else if len(errs) == 0 {
+			fmt.Printf("✅ %s is a valid %s payload\n", args[1], validateAction(kind.Name))
+		} else {
+			for _, e := range errs {
+				fmt.Printf("%s:%d:%d: %s\n", args[1], e.Line, e.Column, e)
+			}
+		}
+
+		if len(errs) > 0 {
+			fmt.Fprintf(os.Stderr, "\n%d problem(s)\n", len(errs))
+			return silentExit(cmd, 1)
+		}
+		return nil
+	}),
+}
+
+// validatePayload checks a create or update payload for kind against the
+// SDK schema before it is sent, unless --no-validate is set. Problems are
+// located in source, the raw --file or --json input, when it is available.
+func validatePayload(cmd *cobra.Command, kind client.ResourceKind, update bool, payload map[string]interface{}, source []byte, sourceName string) error {
+	errs := payloadErrors(cmd, kind, update, payload)
+	if len(errs) == 0 {
+		return nil
+	}
+	if source != nil {
+		schema.Locate(errs, source)
+	}
+	return invalidPayload(sourceName, errs)
+}
+
+// validatePatch checks an update body built with field flags. The body no
+// longer matches any file as written, so instead of line numbers each
+// problem names the flag that changed the field.
+func validatePatch(cmd *cobra.Command, kind client.ResourceKind, payload map[string]interface{}, ops []patch.Op) error {
+	errs := payloadErrors(cmd, kind, true, payload)
+	if len(errs) == 0 {
+		return nil
+	}
+	for i := range errs {
+		if flag := opForPointer(ops, errs[i].Pointer); flag != "" {
+			errs[i].Message += " (from " + flag + ")"
+		}
+	}
+	return invalidPayload("the "+kind.Name+" update", errs)
+}
+
+// payloadErrors validates payload against the SDK schema of kind. Nothing
+// is checked with --no-validate or for kinds without a schema.
+func payloadErrors(cmd *cobra.Command, kind client.ResourceKind, update bool, payload map[string]interface{}) []schema.Error {
+	if skip, _ := cmd.Flags().GetBool("no-validate"); skip {
+		return nil
+	}
+	k, err := schema.Lookup(strings.TrimPrefix(kind.Path, "/"))
+	if err != nil {
+		return nil
+	}
+	return k.Schema(update).Validate(payload)
+}
+
+func invalidPayload(sourceName string, errs []schema.Error) error {
+	verr := &schema.ValidationError{Source: sourceName, Errors: errs}
+	return fmt.Errorf("%w\nFix the payload, or pass --no-validate to send it anyway", verr)
+}
+
+// opForPointer returns the last field flag, such as "--set model.temperature",
+// whose path is pointer, lies inside it or contains it
+func opForPointer(ops []patch.Op, pointer string) string {
+	for i := len(ops) - 1; i >= 0; i-- {
+		p := pathPointer(ops[i].Path)
+		if p == pointer || strings.HasPrefix(pointer, p+"/") || strings.HasPrefix(p, pointer+"/") {
+			return patchFlagNames[ops[i].Kind] + " " + ops[i].Path
+		}
+	}
+	return ""
+}
+
+var patchFlagNames = map[patch.Kind]string{patch.Set: "--set", patch.Unset: "--unset", patch.Append: "--append"}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pathPointer turns a dotted field path such as "model.messages[0].content"
+// into a JSON pointer
+func pathPointer(path string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key != "" {
+			b.WriteString("/" + pointerEscaper.Replace(key))
+		}
+		for rest != "" {
+			index, after, _ := strings.Cut(rest, "]")
+			b.WriteString("/" + index)
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return b.String()
+}
+
+// payloadSourceName describes where the payload of a create or update
+// command came from, for validation messages
+func payloadSourceName(cmd *cobra.Command) string {
+	if filePath, _ := cmd.Flags().GetString("file"); filePath != "" {
+		return filePath
+	}
+	switch jsonStr, _ := cmd.Flags().GetString("json"); jsonStr {
+	case "":
+		return "payload"
+	case "-":
+		return "stdin"
+	default:
+		return "--json"
+	}
+}
+
+// payloadSource returns the raw --file or --json input of a create command
+// so validation problems can be located in it. Stdin has already been
+// consumed and is not returned.
+func payloadSource(cmd *cobra.Command) []byte {
+	if filePath, _ := cmd.Flags().GetString("file"); filePath != "" {
+		data, err := os.ReadFile(filepath.Clean(filePath))
+		if err != nil {
+			return nil
+		}
+		return data
+	}
+	if jsonStr, _ := cmd.Flags().GetString("json"); jsonStr != "" && jsonStr != "-" {
+		return []byte(jsonStr)
+	}
+	return nil
+}
+
+func validateTable(errs []schema.Error) *output.Table {
+	table := &output.Table{Headers: []string{"LINE", "COLUMN", "POINTER", "MESSAGE", "SUGGESTION"}}
+	for _, e := range errs {
+		table.Rows = append(table.Rows, []string{strconv.Itoa(e.Line), strconv.Itoa(e.Column), e.Pointer, e.Message, e.Suggestion})
+	}
+	return table
+}
+
+func validateAction(kind string) string {
+	if validateUpdate {
+		return kind + " update"
+	}
+	return kind + " create"
+}
+
+func validateKindNames() string {
+	names := make([]string, len(schema.Kinds))
+	for i, k := range schema.Kinds {
+		names[i] = k.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().BoolVar(&validateUpdate, "update", false, "Validate as an update (PATCH) payload, where every field is optional")
+}
@@ -19,14 +19,14 @@ Authors:
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/AlecAivazis/survey/v2"
 	vapi "github.com/VapiAI/server-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/VapiAI/cli/pkg/client"
 	"github.com/VapiAI/cli/pkg/output"
 )
 
@@ -49,36 +49,21 @@ var listWorkflowCmd = &cobra.Command{
 	Short: "List all workflows",
 	Long:  `Display all workflows in your account with their IDs, names, and basic configuration.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		fmt.Println("📋 Listing workflows...")
+		fmt.Fprintln(os.Stderr, "📋 Listing workflows...")
 
-		// Fetch all workflows from the API
-		workflows, err := vapiClient.GetClient().Workflow.WorkflowControllerFindAll(ctx)
+		opts, err := listOptionsFromFlags(cmd)
 		if err != nil {
-			// Check if this is a deserialization error related to new features
-			if strings.Contains(err.Error(), "cannot be deserialized") {
-				fmt.Println("⚠️  Warning: The Vapi API returned data in a format not yet supported by this CLI version.")
-				fmt.Println("   This usually happens when new features are added to Vapi.")
-				fmt.Println("   Please check for CLI updates: https://github.com/VapiAI/cli/releases")
-				fmt.Println()
-				fmt.Printf("   Technical details: %v\n", err)
-				return fmt.Errorf("incompatible API response format")
-			}
-			return fmt.Errorf("failed to list workflows: %w", err)
-		}
-
-		if len(workflows) == 0 {
-			fmt.Println("No workflows found. Create one with 'vapi workflow create'")
-			return nil
+			return err
 		}
 
-		// Display in a readable table format
-		fmt.Printf("\nFound %d workflow(s):\n\n", len(workflows))
-		fmt.Printf("%-36s %-30s %-20s\n", "ID", "Name", "Created")
-		fmt.Printf("%-36s %-30s %-20s\n", "----", "----", "-------")
+		it := vapiClient.ListWorkflows(opts)
+		stream := output.NewStream([]string{"ID", "NAME", "CREATED"})
+		defer stream.Abort()
+		for it.Next(ctx) {
+			workflow := it.Item()
 
-		for _, workflow := range workflows {
 			name := workflow.Name
 			if name == "" {
 				name = "Unnamed"
@@ -86,9 +71,37 @@ var listWorkflowCmd = &cobra.Command{
 
 			created := workflow.CreatedAt.Format("2006-01-02 15:04")
 
-			fmt.Printf("%-36s %-30s %-20s\n", workflow.Id, name, created)
+			if err := stream.Add(workflow, []string{workflow.Id, name, created}); err != nil {
+				return fmt.Errorf("failed to display workflows: %w", err)
+			}
+		}
+		count := it.Count()
+		if err := it.Err(); client.IsDecodeError(err) {
+			// The API is ahead of the SDK; finish the list from raw JSON
+			extra, err := continueListRaw(ctx, it, vapiClient.RawUnpaged("/workflow"), stream, func(item map[string]interface{}) []string {
+				return []string{
+					rawField(item, "id", ""),
+					rawField(item, "name", "Unnamed"),
+					rawTime(item, "createdAt", "2006-01-02 15:04"),
+				}
+			})
+			count += extra
+			if err != nil {
+				return fmt.Errorf("failed to list workflows: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to list workflows: %w", err)
+		}
+		if err := stream.Close(); err != nil {
+			return fmt.Errorf("failed to display workflows: %w", err)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(os.Stderr, "No workflows found. Create one with 'vapi workflow create'")
+			return nil
 		}
 
+		fmt.Fprintf(os.Stderr, "\nFound %d workflow(s)\n", count)
 		return nil
 	},
 }
@@ -148,7 +161,7 @@ use the Vapi dashboard at https://dashboard.vapi.ai`,
 
 		fmt.Println("\n🔄 Creating workflow...")
 
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		// Create a basic workflow with a simple conversation node
 		isStart := true
@@ -192,24 +205,30 @@ use the Vapi dashboard at https://dashboard.vapi.ai`,
 }
 
 var getWorkflowCmd = &cobra.Command{
-	Use:   "get [workflow-id]",
+	Use:   "get [workflow-id|name]",
 	Short: "Get details of a specific workflow",
 	Long:  `Retrieve the full configuration of a workflow including nodes, edges, and settings.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		workflowID := args[0]
+		ctx := cmd.Context()
+		workflowID, err := vapiClient.Resolve(ctx, client.Workflows, args[0])
+		if err != nil {
+			return err
+		}
 
-		fmt.Printf("🔍 Getting workflow details for ID: %s\n", workflowID)
+		fmt.Fprintf(os.Stderr, "🔍 Getting workflow details for ID: %s\n", workflowID)
 
 		// Fetch the workflow configuration
 		workflow, err := vapiClient.GetClient().Workflow.WorkflowControllerFindOne(ctx, workflowID)
+		if client.IsDecodeError(err) {
+			return renderRawFallback(ctx, "/workflow/"+workflowID, err)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to get workflow: %w", err)
 		}
 
-		// Display as formatted JSON for easy reading
-		if err := output.PrintJSON(workflow); err != nil {
+		// Display in the selected format (JSON by default)
+		if err := output.Render(workflow, nil); err != nil {
 			return fmt.Errorf("failed to display workflow: %w", err)
 		}
 
@@ -218,39 +237,53 @@ var getWorkflowCmd = &cobra.Command{
 }
 
 var updateWorkflowCmd = &cobra.Command{
-	Use:   "update [workflow-id]",
+	Use:   "update [workflow-id|name]",
 	Short: "Update an existing workflow",
 	Long: `Update a workflow's configuration.
 
+Provide the fields to change as JSON via --json or --file. The previous
+configuration is saved locally first; see 'vapi workflow history'.
+
 Complex updates involving nodes, edges, conditions, or advanced settings 
 are best done through the Vapi dashboard at https://dashboard.vapi.ai`,
+	Example: `  vapi workflow update onboarding --file workflow.json
+  vapi workflow update <workflow-id> --json '{"name":"Onboarding v2"}'
+  vapi workflow update onboarding --set 'nodes[0].prompt=Greet the caller'`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		workflowID := args[0]
-
-		fmt.Printf("📝 Update workflow: %s\n", workflowID)
-		fmt.Println()
-		fmt.Println("Workflow updates are best done through the Vapi dashboard where you can:")
-		fmt.Println("- Visually design conversation flows")
-		fmt.Println("- Add and connect nodes")
-		fmt.Println("- Configure conditions and variables")
-		fmt.Println("- Test workflows interactively")
-		fmt.Println()
-		fmt.Println("Visit: https://dashboard.vapi.ai/workflows")
+		workflow, err := updateFromPayload(cmd, client.Workflows, args[0])
+		if err != nil || workflow == nil {
+			return err
+		}
 
+		fmt.Fprintln(os.Stderr, "✅ Workflow updated successfully")
+		if err := output.Render(workflow, nil); err != nil {
+			return fmt.Errorf("failed to display workflow: %w", err)
+		}
 		return nil
 	},
 }
 
 // nolint:dupl // Delete commands follow a similar pattern across resources
 var deleteWorkflowCmd = &cobra.Command{
-	Use:   "delete [workflow-id]",
+	Use:   "delete [workflow-id|name]",
 	Short: "Delete a workflow",
-	Long:  `Permanently delete a workflow. This cannot be undone.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Permanently delete a workflow. This cannot be undone.
+
+Deletion is refused while phone numbers or other resources still reference
+the workflow. Use --force to delete it anyway.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		workflowID := args[0]
+		ctx := cmd.Context()
+		workflowID, err := vapiClient.Resolve(ctx, client.Workflows, args[0])
+		if err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if err := checkDependents(ctx, "workflow", workflowID, force); err != nil {
+			return err
+		}
 
 		// Require explicit confirmation for destructive actions
 		var confirmDelete bool
@@ -264,19 +297,20 @@ var deleteWorkflowCmd = &cobra.Command{
 		}
 
 		if !confirmDelete {
-			fmt.Println("Deletion canceled.")
+			fmt.Fprintln(os.Stderr, "Deletion canceled.")
 			return nil
 		}
 
-		fmt.Printf("🗑️  Deleting workflow with ID: %s\n", workflowID)
+		fmt.Fprintf(os.Stderr, "🗑️  Deleting workflow with ID: %s\n", workflowID)
 
 		// Execute deletion via API
-		_, err := vapiClient.GetClient().Workflow.WorkflowControllerDelete(ctx, workflowID)
+		_, err = vapiClient.GetClient().Workflow.WorkflowControllerDelete(ctx, workflowID)
 		if err != nil {
 			return fmt.Errorf("failed to delete workflow: %w", err)
 		}
+		vapiClient.ForgetNames(client.Workflows)
 
-		fmt.Println("✅ Workflow deleted successfully")
+		fmt.Fprintln(os.Stderr, "✅ Workflow deleted successfully")
 		return nil
 	},
 }
@@ -287,5 +321,13 @@ func init() {
 	workflowCmd.AddCommand(createWorkflowCmd)
 	workflowCmd.AddCommand(getWorkflowCmd)
 	workflowCmd.AddCommand(updateWorkflowCmd)
+	workflowCmd.AddCommand(newHistoryCmd("workflow", client.Workflows))
+	workflowCmd.AddCommand(newRollbackCmd("workflow", client.Workflows))
 	workflowCmd.AddCommand(deleteWorkflowCmd)
+
+	addListFlags(listWorkflowCmd)
+
+	addUpdateFlags(updateWorkflowCmd, "workflow")
+
+	deleteWorkflowCmd.Flags().Bool("force", false, "Delete even if phone numbers or other resources still reference it")
 }
@@ -0,0 +1,262 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// refPrefix marks a string value in a spec as a reference to another
+// manifest, e.g. "ref:Tool/lookup-order". References are replaced with the
+// resource's ID when the change is applied.
+const refPrefix = "ref:"
+
+// Kind is a resource type that can be managed with manifests
+type Kind struct {
+	Name      string              // Manifest kind, e.g. "Assistant"
+	Resource  client.ResourceKind // API endpoint and name fields
+	NameField string              // Payload path the manifest name is written to
+}
+
+// Kinds lists every manageable kind in dependency order: a kind may only
+// reference kinds that come before it
+var Kinds = []Kind{
+	{Name: "Tool", Resource: client.Tools, NameField: "function.name"},
+	{Name: "Assistant", Resource: client.Assistants, NameField: "name"},
+	{Name: "Workflow", Resource: client.Workflows, NameField: "name"},
+	{Name: "PhoneNumber", Resource: client.PhoneNumbers, NameField: "name"},
+}
+
+// LookupKind finds a kind by name, ignoring case, dashes and underscores
+func LookupKind(name string) (Kind, bool) {
+	normalized := normalizeKind(name)
+	for _, kind := range Kinds {
+		if normalizeKind(kind.Name) == normalized {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+func normalizeKind(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "-", "")
+	return strings.ReplaceAll(name, "_", "")
+}
+
+// order returns the kind's position in dependency order
+func (k Kind) order() int {
+	for i, kind := range Kinds {
+		if kind.Name == k.Name {
+			return i
+		}
+	}
+	return len(Kinds)
+}
+
+// Manifest is the desired state of one resource
+type Manifest struct {
+	Kind   Kind
+	Name   string
+	Spec   map[string]interface{} // API payload, without server-managed fields
+	Source string                 // File the manifest was read from
+}
+
+// ID returns the manifest's "Kind/name" identifier
+func (m *Manifest) ID() string {
+	return m.Kind.Name + "/" + m.Name
+}
+
+type rawManifest struct {
+	Kind string                 `yaml:"kind" json:"kind"`
+	Name string                 `yaml:"name" json:"name"`
+	Spec map[string]interface{} `yaml:"spec" json:"spec"`
+}
+
+// LoadManifests reads every manifest in paths. Directories are walked for
+// .yaml, .yml and .json files, and YAML files may hold several documents
+// separated by "---".
+func LoadManifests(paths []string) ([]*Manifest, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isManifestFile(p) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+
+	var manifests []*Manifest
+	seen := make(map[string]string)
+	for _, file := range files {
+		loaded, err := loadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range loaded {
+			if prev, ok := seen[m.ID()]; ok {
+				return nil, fmt.Errorf("%s is defined twice (%s and %s)", m.ID(), prev, m.Source)
+			}
+			seen[m.ID()] = m.Source
+			manifests = append(manifests, m)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no manifests found in %s", strings.Join(paths, ", "))
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return manifests[i].Kind.order() < manifests[j].Kind.order()
+	})
+	return manifests, nil
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func loadFile(path string) ([]*Manifest, error) {
+	data, err := os.ReadFile(filepath.Clean(path)) // #nosec G304 - manifest paths are user-provided
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifests []*Manifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for doc := 1; ; doc++ {
+		var raw rawManifest
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if raw.Kind == "" && raw.Name == "" && raw.Spec == nil {
+			continue // Empty document
+		}
+
+		m, err := newManifest(raw, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s (document %d): %w", path, doc, err)
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+func newManifest(raw rawManifest, source string) (*Manifest, error) {
+	if raw.Kind == "" {
+		return nil, fmt.Errorf("missing kind")
+	}
+	kind, ok := LookupKind(raw.Kind)
+	if !ok {
+		names := make([]string, len(Kinds))
+		for i, k := range Kinds {
+			names[i] = k.Name
+		}
+		return nil, fmt.Errorf("unknown kind %q (valid: %s)", raw.Kind, strings.Join(names, ", "))
+	}
+	if strings.TrimSpace(raw.Name) == "" {
+		return nil, fmt.Errorf("missing name")
+	}
+
+	// Round-trip through JSON so YAML values compare equal to API responses
+	spec := map[string]interface{}{}
+	if raw.Spec != nil {
+		b, err := json.Marshal(raw.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec: %w", err)
+		}
+		if err := json.Unmarshal(b, &spec); err != nil {
+			return nil, fmt.Errorf("invalid spec: %w", err)
+		}
+	}
+	spec = client.StripServerFields(spec)
+	if err := setName(spec, kind.NameField, raw.Name); err != nil {
+		return nil, err
+	}
+
+	return &Manifest{Kind: kind, Name: raw.Name, Spec: spec, Source: source}, nil
+}
+
+// setName writes name to a dotted path in spec. A nested path needs its
+// parent object: without it the resource would be created unnamed, never
+// match its manifest again, and be duplicated on every apply.
+func setName(spec map[string]interface{}, path, name string) error {
+	keys := strings.Split(path, ".")
+	cur := spec
+	for i, key := range keys[:len(keys)-1] {
+		next, ok := cur[key].(map[string]interface{})
+		if !ok {
+			parent := strings.Join(keys[:i+1], ".")
+			return fmt.Errorf("spec needs a %q object to hold the name (%s); add \"%s: {}\" if it has nothing else to set", parent, path, parent)
+		}
+		cur = next
+	}
+	cur[keys[len(keys)-1]] = name
+	return nil
+}
+
+// parseRef splits a "ref:Kind/name" value
+func parseRef(value string) (Kind, string, bool, error) {
+	if !strings.HasPrefix(value, refPrefix) {
+		return Kind{}, "", false, nil
+	}
+	target := strings.TrimPrefix(value, refPrefix)
+	kindName, name, ok := strings.Cut(target, "/")
+	if !ok || name == "" {
+		return Kind{}, "", true, fmt.Errorf("invalid reference %q (expected ref:Kind/name)", value)
+	}
+	kind, found := LookupKind(kindName)
+	if !found {
+		return Kind{}, "", true, fmt.Errorf("invalid reference %q: unknown kind %q", value, kindName)
+	}
+	return kind, name, true, nil
+}
@@ -0,0 +1,83 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestLoadManifests(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "assistants/support.yaml", `
+kind: assistant
+name: support
+spec:
+  id: ignored
+  maxTokens: 250
+  model:
+    toolIds: [ref:Tool/lookup]
+`)
+	writeFile(t, dir, "tools.yaml", `
+kind: Tool
+name: lookup
+spec:
+  type: function
+  function: {description: Look up an order}
+---
+kind: Tool
+name: hang-up
+spec:
+  type: endCall
+  function: {}
+`)
+	writeFile(t, dir, "README.md", "not a manifest")
+
+	manifests, err := LoadManifests([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+
+	// Sorted into dependency order
+	assert.Equal(t, "Tool", manifests[0].Kind.Name)
+	assert.Equal(t, "Tool", manifests[1].Kind.Name)
+	assert.Equal(t, "Assistant/support", manifests[2].ID())
+
+	support := manifests[2].Spec
+	assert.Equal(t, "support", support["name"])
+	assert.Equal(t, float64(250), support["maxTokens"])
+	assert.NotContains(t, support, "id")
+
+	// Tool names go into function.name, whatever the tool type
+	for _, m := range manifests[:2] {
+		assert.Equal(t, m.Name, m.Spec["function"].(map[string]interface{})["name"])
+	}
+}
+
+func TestLoadManifestsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown kind", content: "kind: Squad\nname: x\n", err: `unknown kind "Squad"`},
+		{name: "missing name", content: "kind: Tool\n", err: "missing name"},
+		{name: "duplicate", content: "kind: Tool\nname: x\nspec: {function: {}}\n---\nkind: tool\nname: x\nspec: {function: {}}\n", err: "Tool/x is defined twice"},
+		{name: "tool without function", content: "kind: Tool\nname: x\nspec: {type: transferCall}\n", err: `spec needs a "function" object to hold the name`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "m.yaml", tt.content)
+			_, err := LoadManifests([]string{dir})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,467 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// knownAfterApply stands in for the ID of a resource that doesn't exist yet
+const knownAfterApply = "(known after apply)"
+
+// Remote is the API surface apply needs, satisfied by *client.VapiClient
+type Remote interface {
+	ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error)
+	CreateRaw(ctx context.Context, kind client.ResourceKind, body map[string]interface{}) (map[string]interface{}, error)
+	UpdateRaw(ctx context.Context, kind client.ResourceKind, id string, body map[string]interface{}) (map[string]interface{}, error)
+	DeleteRaw(ctx context.Context, kind client.ResourceKind, id string) error
+}
+
+// Action is what applying a change does to a remote resource
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// FieldChange is one differing field, addressed by its dotted path
+type FieldChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Change is the planned action for one resource
+type Change struct {
+	Action Action        `json:"action"`
+	Kind   string        `json:"kind"`
+	Name   string        `json:"name"`
+	ID     string        `json:"id,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	kind     Kind
+	manifest *Manifest
+	remote   map[string]interface{} // The remote resource an update applies to
+}
+
+// Ref returns the change's "Kind/name" identifier
+func (c *Change) Ref() string {
+	return c.Kind + "/" + c.Name
+}
+
+// Plan is the ordered set of changes that brings the account in line with
+// the manifests. Creates and updates come in dependency order, deletes in
+// reverse.
+type Plan struct {
+	Changes []*Change `json:"changes"`
+
+	// ids maps "Kind/name" to the remote ID of every uniquely named
+	// resource, so references can be resolved
+	ids map[string]string
+}
+
+// Count returns how many changes have action
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges reports whether applying the plan would modify anything
+func (p *Plan) HasChanges() bool {
+	return len(p.Changes) > p.Count(ActionUnchanged)
+}
+
+// Options controls planning
+type Options struct {
+	Prune bool // Delete remote resources of the managed kinds that have no manifest
+}
+
+// BuildPlan compares manifests with the remote resources and returns the
+// changes needed to reconcile them
+func BuildPlan(ctx context.Context, remote Remote, manifests []*Manifest, opts Options) (*Plan, error) {
+	// Fetch every kind that is managed or referenced
+	managed := make(map[string]bool)
+	needed := make(map[string]Kind)
+	for _, m := range manifests {
+		managed[m.Kind.Name] = true
+		needed[m.Kind.Name] = m.Kind
+		err := walkRefs(m.Spec, func(kind Kind, name string) error {
+			if kind.order() >= m.Kind.order() {
+				return fmt.Errorf("%s: %s cannot reference %s/%s; references must point to an earlier kind (%s)", m.Source, m.ID(), kind.Name, name, kindOrder())
+			}
+			needed[kind.Name] = kind
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	remoteByKind := make(map[string][]map[string]interface{})
+	for _, kind := range Kinds {
+		if _, ok := needed[kind.Name]; !ok {
+			continue
+		}
+		items, err := remote.ListAllRaw(ctx, kind.Resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", kind.Resource.Name, err)
+		}
+		remoteByKind[kind.Name] = items
+	}
+
+	plan := &Plan{ids: make(map[string]string)}
+	ambiguous := make(map[string]bool)
+	for kindName, items := range remoteByKind {
+		kind, _ := LookupKind(kindName)
+		for _, item := range items {
+			id, _ := item["id"].(string)
+			for _, name := range namesOf(kind, item) {
+				ref := kindName + "/" + name
+				if prev, dup := plan.ids[ref]; dup && prev != id {
+					ambiguous[ref] = true
+				}
+				plan.ids[ref] = id
+			}
+		}
+	}
+	for ref := range ambiguous {
+		delete(plan.ids, ref)
+	}
+
+	matched := make(map[string]bool)
+	for _, m := range manifests {
+		if ambiguous[m.ID()] {
+			return nil, fmt.Errorf("%s: more than one remote %s is named %q; rename or delete the duplicates first", m.Source, m.Kind.Resource.Name, m.Name)
+		}
+
+		desired, err := plan.resolveRefs(m.Spec, true)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Source, err)
+		}
+
+		change := &Change{Kind: m.Kind.Name, Name: m.Name, kind: m.Kind, manifest: m}
+		id, exists := plan.ids[m.ID()]
+		if !exists {
+			change.Action = ActionCreate
+			change.Fields = diffFields("", desired, nil)
+		} else {
+			matched[id] = true
+			change.ID = id
+			change.remote = findByID(remoteByKind[m.Kind.Name], id)
+			change.Fields = diffFields("", desired, change.remote)
+			change.Action = ActionUpdate
+			if len(change.Fields) == 0 {
+				change.Action = ActionUnchanged
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		for i := len(Kinds) - 1; i >= 0; i-- {
+			kind := Kinds[i]
+			if !managed[kind.Name] {
+				continue
+			}
+			for _, item := range remoteByKind[kind.Name] {
+				id, _ := item["id"].(string)
+				if matched[id] {
+					continue
+				}
+				name := kind.Resource.NameOf(item)
+				if name == "" {
+					name = id
+				}
+				plan.Changes = append(plan.Changes, &Change{Action: ActionDelete, Kind: kind.Name, Name: name, ID: id, kind: kind})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply executes the plan against remote, reporting each step to out
+func Apply(ctx context.Context, remote Remote, plan *Plan, out io.Writer) error {
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case ActionCreate:
+			body, err := plan.resolveRefs(c.manifest.Spec, false)
+			if err != nil {
+				return err
+			}
+			created, err := remote.CreateRaw(ctx, c.kind.Resource, body)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", c.Ref(), err)
+			}
+			c.ID, _ = created["id"].(string)
+			plan.ids[c.Ref()] = c.ID
+			fmt.Fprintf(out, "✅ Created %s (%s)\n", c.Ref(), c.ID)
+
+		case ActionUpdate:
+			spec, err := plan.resolveRefs(c.manifest.Spec, false)
+			if err != nil {
+				return err
+			}
+			// PATCH only the top-level fields that changed. A PATCH replaces
+			// nested objects whole, so the manifest's fields are merged over
+			// the remote object to leave fields it doesn't mention alone.
+			body := make(map[string]interface{})
+			for _, f := range c.Fields {
+				key, _, _ := strings.Cut(f.Path, ".")
+				body[key] = mergeValues(c.remote[key], spec[key])
+			}
+			if _, err := remote.UpdateRaw(ctx, c.kind.Resource, c.ID, body); err != nil {
+				return fmt.Errorf("failed to update %s: %w", c.Ref(), err)
+			}
+			fmt.Fprintf(out, "✅ Updated %s (%s)\n", c.Ref(), c.ID)
+
+		case ActionDelete:
+			if err := remote.DeleteRaw(ctx, c.kind.Resource, c.ID); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", c.Ref(), err)
+			}
+			fmt.Fprintf(out, "🗑️  Deleted %s (%s)\n", c.Ref(), c.ID)
+		}
+	}
+	return nil
+}
+
+// Write prints a human-readable plan
+func (p *Plan) Write(w io.Writer) {
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete, %d unchanged\n",
+		p.Count(ActionCreate), p.Count(ActionUpdate), p.Count(ActionDelete), p.Count(ActionUnchanged))
+
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "\n+ %s\n", c.Ref())
+		case ActionUpdate:
+			fmt.Fprintf(w, "\n~ %s (%s)\n", c.Ref(), c.ID)
+		case ActionDelete:
+			fmt.Fprintf(w, "\n- %s (%s)\n", c.Ref(), c.ID)
+			continue
+		default:
+			continue
+		}
+		for _, f := range c.Fields {
+			switch {
+			case f.Old == nil:
+				fmt.Fprintf(w, "    + %s: %s\n", f.Path, formatValue(f.New))
+			default:
+				fmt.Fprintf(w, "    ~ %s: %s → %s\n", f.Path, formatValue(f.Old), formatValue(f.New))
+			}
+		}
+	}
+}
+
+// resolveRefs returns a copy of spec with every "ref:Kind/name" replaced by
+// the referenced resource's ID. While planning, resources that will only
+// exist after apply get a placeholder.
+func (p *Plan) resolveRefs(spec map[string]interface{}, planning bool) (map[string]interface{}, error) {
+	var resolve func(v interface{}) (interface{}, error)
+	resolve = func(v interface{}) (interface{}, error) {
+		switch value := v.(type) {
+		case string:
+			kind, name, ok, err := parseRef(value)
+			if err != nil || !ok {
+				return value, err
+			}
+			ref := kind.Name + "/" + name
+			if id, found := p.ids[ref]; found {
+				return id, nil
+			}
+			if planning && p.creates(ref) {
+				return knownAfterApply, nil
+			}
+			return nil, fmt.Errorf("reference %q does not match any manifest or remote %s", value, kind.Resource.Name)
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(value))
+			for k, item := range value {
+				resolved, err := resolve(item)
+				if err != nil {
+					return nil, err
+				}
+				out[k] = resolved
+			}
+			return out, nil
+		case []interface{}:
+			out := make([]interface{}, len(value))
+			for i, item := range value {
+				resolved, err := resolve(item)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = resolved
+			}
+			return out, nil
+		default:
+			return value, nil
+		}
+	}
+
+	resolved, err := resolve(spec)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]interface{}), nil
+}
+
+// creates reports whether ref is created by a change already in the plan
+func (p *Plan) creates(ref string) bool {
+	for _, c := range p.Changes {
+		if c.Action == ActionCreate && c.Ref() == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// walkRefs calls fn for every reference in v
+func walkRefs(v interface{}, fn func(kind Kind, name string) error) error {
+	switch value := v.(type) {
+	case string:
+		kind, name, ok, err := parseRef(value)
+		if err != nil || !ok {
+			return err
+		}
+		return fn(kind, name)
+	case map[string]interface{}:
+		for _, item := range value {
+			if err := walkRefs(item, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := walkRefs(item, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeValues returns desired with any object fields it lacks filled in
+// from actual. Arrays and scalars in desired replace actual outright, as
+// diffFields compares them.
+func mergeValues(actual, desired interface{}) interface{} {
+	desiredMap, ok := desired.(map[string]interface{})
+	if !ok {
+		return desired
+	}
+	actualMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return desired
+	}
+	merged := make(map[string]interface{}, len(actualMap)+len(desiredMap))
+	for k, v := range actualMap {
+		merged[k] = v
+	}
+	for k, v := range desiredMap {
+		merged[k] = mergeValues(actualMap[k], v)
+	}
+	return merged
+}
+
+// diffFields lists the fields of desired that differ from actual. Fields
+// only present remotely (IDs, timestamps, server defaults) are ignored.
+// Nested objects are compared field by field, arrays as a whole.
+func diffFields(prefix string, desired, actual map[string]interface{}) []FieldChange {
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var changes []FieldChange
+	for _, k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		want := desired[k]
+		have, exists := actual[k]
+
+		wantMap, wantIsMap := want.(map[string]interface{})
+		haveMap, haveIsMap := have.(map[string]interface{})
+		if wantIsMap && (haveIsMap || !exists) {
+			changes = append(changes, diffFields(path, wantMap, haveMap)...)
+			continue
+		}
+		if !exists || !reflect.DeepEqual(want, have) {
+			changes = append(changes, FieldChange{Path: path, Old: have, New: want})
+		}
+	}
+	return changes
+}
+
+func namesOf(kind Kind, item map[string]interface{}) []string {
+	var names []string
+	for _, field := range kind.Resource.NameFields {
+		if name := client.LookupString(item, field); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func findByID(items []map[string]interface{}, id string) map[string]interface{} {
+	for _, item := range items {
+		if itemID, _ := item["id"].(string); itemID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func kindOrder() string {
+	names := make([]string, len(Kinds))
+	for i, k := range Kinds {
+		names[i] = k.Name
+	}
+	return strings.Join(names, " → ")
+}
+
+// formatValue renders a field value on one line, truncating long values
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	s := string(b)
+	if r := []rune(s); len(r) > 80 {
+		s = string(r[:77]) + "..."
+	}
+	return s
+}
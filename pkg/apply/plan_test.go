@@ -0,0 +1,141 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// fakeRemote records writes against in-memory resources
+type fakeRemote struct {
+	items map[string][]map[string]interface{}
+	log   []string
+	next  int
+}
+
+func (f *fakeRemote) ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error) {
+	return f.items[kind.Path], nil
+}
+
+func (f *fakeRemote) CreateRaw(ctx context.Context, kind client.ResourceKind, body map[string]interface{}) (map[string]interface{}, error) {
+	f.next++
+	id := fmt.Sprintf("new-%d", f.next)
+	f.log = append(f.log, fmt.Sprintf("create %s %v", kind.Path, body))
+	return map[string]interface{}{"id": id}, nil
+}
+
+func (f *fakeRemote) UpdateRaw(ctx context.Context, kind client.ResourceKind, id string, body map[string]interface{}) (map[string]interface{}, error) {
+	f.log = append(f.log, fmt.Sprintf("update %s/%s %v", kind.Path, id, body))
+	return body, nil
+}
+
+func (f *fakeRemote) DeleteRaw(ctx context.Context, kind client.ResourceKind, id string) error {
+	f.log = append(f.log, fmt.Sprintf("delete %s/%s", kind.Path, id))
+	return nil
+}
+
+func manifest(t *testing.T, kind, name string, spec map[string]interface{}) *Manifest {
+	t.Helper()
+	m, err := newManifest(rawManifest{Kind: kind, Name: name, Spec: spec}, "test.yaml")
+	require.NoError(t, err)
+	return m
+}
+
+func TestBuildPlanAndApply(t *testing.T) {
+	remote := &fakeRemote{items: map[string][]map[string]interface{}{
+		"/assistant": {
+			{"id": "a1", "name": "support", "firstMessage": "Hi", "model": map[string]interface{}{"provider": "openai", "temperature": 0.7}, "orgId": "o1"},
+			{"id": "a2", "name": "sales", "firstMessage": "Hello"},
+			{"id": "a3", "name": "legacy"},
+		},
+	}}
+
+	manifests := []*Manifest{
+		manifest(t, "Tool", "lookup", map[string]interface{}{"type": "function", "function": map[string]interface{}{}}),
+		manifest(t, "Assistant", "support", map[string]interface{}{
+			"firstMessage": "Hi",
+			"model":        map[string]interface{}{"provider": "openai", "temperature": 0.3, "toolIds": []interface{}{"ref:Tool/lookup"}},
+		}),
+		manifest(t, "Assistant", "sales", map[string]interface{}{"firstMessage": "Hello"}),
+	}
+
+	plan, err := BuildPlan(context.Background(), remote, manifests, Options{Prune: true})
+	require.NoError(t, err)
+
+	var summary []string
+	for _, c := range plan.Changes {
+		summary = append(summary, fmt.Sprintf("%s %s", c.Action, c.Ref()))
+	}
+	assert.Equal(t, []string{"create Tool/lookup", "update Assistant/support", "unchanged Assistant/sales", "delete Assistant/legacy"}, summary)
+
+	support := plan.Changes[1]
+	assert.Equal(t, []FieldChange{
+		{Path: "model.temperature", Old: 0.7, New: 0.3},
+		{Path: "model.toolIds", New: []interface{}{knownAfterApply}},
+	}, support.Fields)
+
+	var out bytes.Buffer
+	plan.Write(&out)
+	assert.Contains(t, out.String(), "Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged")
+	assert.Contains(t, out.String(), "~ model.temperature: 0.7 → 0.3")
+
+	require.NoError(t, Apply(context.Background(), remote, plan, &bytes.Buffer{}))
+	assert.Equal(t, []string{
+		"create /tool map[function:map[name:lookup] type:function]",
+		"update /assistant/a1 map[model:map[provider:openai temperature:0.3 toolIds:[new-1]]]",
+		"delete /assistant/a3",
+	}, remote.log)
+}
+
+func TestApplyKeepsRemoteSiblingFields(t *testing.T) {
+	remote := &fakeRemote{items: map[string][]map[string]interface{}{
+		"/assistant": {{
+			"id":   "a1",
+			"name": "support",
+			"model": map[string]interface{}{
+				"provider":      "openai",
+				"temperature":   0.7,
+				"messages":      []interface{}{map[string]interface{}{"role": "system", "content": "Be brief"}},
+				"toolIds":       []interface{}{"t1"},
+				"knowledgeBase": map[string]interface{}{"provider": "custom-knowledge-base", "server": map[string]interface{}{"url": "https://kb.example.com"}},
+			},
+		}},
+	}}
+	manifests := []*Manifest{
+		manifest(t, "Assistant", "support", map[string]interface{}{
+			"model": map[string]interface{}{
+				"temperature":   0.2,
+				"knowledgeBase": map[string]interface{}{"server": map[string]interface{}{"timeoutSeconds": 5}},
+			},
+		}),
+	}
+
+	plan, err := BuildPlan(context.Background(), remote, manifests, Options{})
+	require.NoError(t, err)
+	require.NoError(t, Apply(context.Background(), remote, plan, &bytes.Buffer{}))
+
+	// The system prompt, tool IDs and knowledge base URL survive the PATCH
+	assert.Equal(t, []string{
+		"update /assistant/a1 map[model:map[knowledgeBase:map[provider:custom-knowledge-base server:map[timeoutSeconds:5 url:https://kb.example.com]] messages:[map[content:Be brief role:system]] provider:openai temperature:0.2 toolIds:[t1]]]",
+	}, remote.log)
+}
+
+func TestBuildPlanRefErrors(t *testing.T) {
+	remote := &fakeRemote{}
+
+	_, err := BuildPlan(context.Background(), remote, []*Manifest{
+		manifest(t, "Assistant", "support", map[string]interface{}{"model": map[string]interface{}{"toolIds": []interface{}{"ref:Tool/missing"}}}),
+	}, Options{})
+	assert.ErrorContains(t, err, `reference "ref:Tool/missing" does not match`)
+
+	_, err = BuildPlan(context.Background(), remote, []*Manifest{
+		manifest(t, "Tool", "t", map[string]interface{}{"function": map[string]interface{}{}, "assistantId": "ref:Assistant/support"}),
+	}, Options{})
+	assert.ErrorContains(t, err, "references must point to an earlier kind")
+}
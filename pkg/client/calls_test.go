@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/config"
+)
+
+func TestControlCall(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/c1/control", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received["type"] != "end-call" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"unknown control message"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	v := &VapiClient{
+		config:     &config.Config{APIKey: "sk-test", BaseURL: "https://api.invalid"},
+		httpClient: server.Client(),
+		apiKey:     "sk-test",
+	}
+	ctx := context.Background()
+
+	require.NoError(t, v.ControlCall(ctx, server.URL+"/c1/control", map[string]interface{}{"type": "end-call"}))
+	assert.Equal(t, "end-call", received["type"])
+
+	err := v.ControlCall(ctx, server.URL+"/c1/control", map[string]interface{}{"type": "mute"})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "unknown control message", apiErr.Message())
+}
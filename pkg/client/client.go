@@ -22,10 +22,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	vapiclient "github.com/VapiAI/server-sdk-go/client"
 	"github.com/VapiAI/server-sdk-go/option"
@@ -34,8 +37,12 @@ import (
 )
 
 type VapiClient struct {
-	client *vapiclient.Client
-	config *config.Config
+	client     *vapiclient.Client
+	config     *config.Config
+	httpClient *http.Client
+	names      *NameCache
+	history    *HistoryStore
+	apiKey     string
 }
 
 func NewVapiClient(apiKey string) (*VapiClient, error) {
@@ -52,9 +59,42 @@ func NewVapiClient(apiKey string) (*VapiClient, error) {
 	// Set API key from parameter
 	cfg.APIKey = apiKey
 
+	return newVapiClient(cfg, apiKey), nil
+}
+
+// NewVapiClientForAccount creates a client for a configured account, such as
+// the target of a cross-account copy, using that account's API key and
+// environment
+func NewVapiClientForAccount(accountKey string) (*VapiClient, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.UseAccount(accountKey); err != nil {
+		return nil, err
+	}
+	return newVapiClient(cfg, cfg.Accounts[accountKey].APIKey), nil
+}
+
+func newVapiClient(cfg *config.Config, apiKey string) *VapiClient {
+	// Share one retrying HTTP client between the SDK and raw requests.
+	// The SDK's own retrier is limited to a single attempt so the two
+	// policies don't multiply. Debug tracing sits below the retrier so every
+	// attempt is logged.
+	var transport http.RoundTripper = http.DefaultTransport
+	if debugLogger != nil {
+		transport = NewDebugTransport(transport, debugLogger)
+	}
+	httpClient := &http.Client{
+		Transport: NewRetryTransport(transport, RetryPolicyFromConfig(cfg)),
+		Timeout:   RequestTimeout(cfg),
+	}
+
 	// Create client with environment-specific base URL
 	options := []option.RequestOption{
 		option.WithToken(apiKey),
+		option.WithHTTPClient(httpClient),
+		option.WithMaxAttempts(1),
 	}
 
 	// Add base URL if not production
@@ -65,9 +105,22 @@ func NewVapiClient(apiKey string) (*VapiClient, error) {
 	client := vapiclient.NewClient(options...)
 
 	return &VapiClient{
-		client: client,
-		config: cfg,
-	}, nil
+		client:     client,
+		config:     cfg,
+		httpClient: httpClient,
+		names:      NewNameCache(DefaultNameCachePath()),
+		history:    NewHistoryStore(DefaultHistoryDir()),
+		apiKey:     apiKey,
+	}
+}
+
+// RequestTimeout returns the configured per-request timeout, including
+// retries. Zero means requests are only bounded by the command's context.
+func RequestTimeout(cfg *config.Config) time.Duration {
+	if cfg == nil || cfg.Timeout <= 0 {
+		return 0
+	}
+	return time.Duration(cfg.Timeout) * time.Second
 }
 
 func (v *VapiClient) GetClient() *vapiclient.Client {
@@ -78,28 +131,90 @@ func (v *VapiClient) GetConfig() *config.Config {
 	return v.config
 }
 
-// DoRawJSON sends a raw JSON request to the Vapi API using the underlying client.
-// path should be like "/assistants/<id>". method is e.g. "PATCH".
-func (v *VapiClient) DoRawJSON(ctx context.Context, method, path string, body []byte) (map[string]interface{}, error) {
+// RawRequest is an arbitrary authenticated request against the Vapi API
+type RawRequest struct {
+	Method string
+	Path   string // Relative to the API base URL, e.g. "/squad"
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// RawResponse is the undecoded result of a RawRequest
+type RawResponse struct {
+	StatusCode int
+	Status     string
+	Proto      string
+	Header     http.Header
+	Body       []byte
+}
+
+// APIError is a non-2xx response from a raw request
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body)
+}
+
+// Message returns the API's explanation of the error, joining the list of
+// validation messages a 400 carries, or the raw body if it has none
+func (e *APIError) Message() string {
+	var body struct {
+		Message interface{} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &body); err == nil {
+		switch msg := body.Message.(type) {
+		case string:
+			return msg
+		case []interface{}:
+			parts := make([]string, 0, len(msg))
+			for _, m := range msg {
+				parts = append(parts, fmt.Sprint(m))
+			}
+			return strings.Join(parts, "\n")
+		}
+	}
+	return strings.TrimSpace(e.Body)
+}
+
+// IsNotFound reports whether err is a 404 from the API
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// DoRaw sends req with the active account's API key and environment. Non-2xx
+// responses are returned as-is rather than as errors.
+func (v *VapiClient) DoRaw(ctx context.Context, req *RawRequest) (*RawResponse, error) {
 	baseURL := strings.TrimRight(v.config.GetAPIBaseURL(), "/")
-	rel := "/" + strings.TrimLeft(path, "/")
-	url := baseURL + rel
+	rel := "/" + strings.TrimLeft(req.Path, "/")
+	target := baseURL + rel
+	if len(req.Query) > 0 {
+		target += "?" + req.Query.Encode()
+	}
 
-	var bodyReader io.Reader
-	if body != nil {
-		bodyReader = bytes.NewReader(body)
-	} else {
-		bodyReader = http.NoBody
+	var bodyReader io.Reader = http.NoBody
+	if req.Body != nil {
+		bodyReader = bytes.NewReader(req.Body)
 	}
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+v.config.GetActiveAPIKey())
+	httpReq.Header.Set("Accept", "application/json")
+	for name, values := range req.Header {
+		httpReq.Header.Del(name)
+		for _, value := range values {
+			httpReq.Header.Add(name, value)
+		}
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+v.apiKey)
 
-	httpClient := &http.Client{}
-	httpResp, err := httpClient.Do(httpReq)
+	httpResp, err := v.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -110,15 +225,32 @@ func (v *VapiClient) DoRawJSON(ctx context.Context, method, path string, body []
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		return nil, fmt.Errorf("API error %d: %s", httpResp.StatusCode, string(respBytes))
+	return &RawResponse{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Proto:      httpResp.Proto,
+		Header:     httpResp.Header,
+		Body:       respBytes,
+	}, nil
+}
+
+// DoRawJSON sends a raw JSON request to the Vapi API using the underlying client.
+// path should be like "/assistants/<id>". method is e.g. "PATCH".
+func (v *VapiClient) DoRawJSON(ctx context.Context, method, path string, body []byte) (map[string]interface{}, error) {
+	resp, err := v.DoRaw(ctx, &RawRequest{Method: method, Path: path, Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(resp.Body)}
 	}
 
-	var resp map[string]interface{}
-	if len(respBytes) > 0 {
-		if err := json.Unmarshal(respBytes, &resp); err != nil {
+	var result map[string]interface{}
+	if len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, &result); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON response: %w", err)
 		}
 	}
-	return resp, nil
+	return result, nil
 }
@@ -0,0 +1,289 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxDebugBody caps how much of each body is printed to the console
+const maxDebugBody = 64 * 1024
+
+const redacted = "[REDACTED]"
+
+// requestIDHeaders are checked in order for an ID to quote to Vapi support
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Amzn-Trace-Id", "Cf-Ray"}
+
+// DebugRecord is one HTTP exchange as written to --debug-file
+type DebugRecord struct {
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Status          int               `json:"status,omitempty"`
+	DurationMs      int64             `json:"durationMs"`
+	RequestID       string            `json:"requestId,omitempty"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     json.RawMessage   `json:"requestBody,omitempty"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    json.RawMessage   `json:"responseBody,omitempty"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// DebugLogger writes redacted HTTP exchanges to the console, a JSONL file,
+// or both
+type DebugLogger struct {
+	mu      sync.Mutex
+	console io.Writer
+	file    io.Writer
+}
+
+// NewDebugLogger creates a logger. Either writer may be nil.
+func NewDebugLogger(console, file io.Writer) *DebugLogger {
+	return &DebugLogger{console: console, file: file}
+}
+
+// debugLogger is installed by --debug / --debug-file before clients are created
+var debugLogger *DebugLogger
+
+// SetDebugLogger enables HTTP tracing for clients created afterwards
+func SetDebugLogger(logger *DebugLogger) {
+	debugLogger = logger
+}
+
+// Log writes one exchange
+func (l *DebugLogger) Log(record *DebugRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.console != nil {
+		l.writeConsole(record)
+	}
+	if l.file != nil {
+		if line, err := json.Marshal(record); err == nil {
+			_, _ = l.file.Write(append(line, '\n'))
+		}
+	}
+}
+
+func (l *DebugLogger) writeConsole(r *DebugRecord) {
+	w := l.console
+	fmt.Fprintf(w, "[debug] → %s %s\n", r.Method, r.URL)
+	writeDebugHeaders(w, r.RequestHeaders)
+	writeDebugBody(w, r.RequestBody)
+
+	if r.Error != "" {
+		fmt.Fprintf(w, "[debug] ✗ %s (%dms)\n", r.Error, r.DurationMs)
+		return
+	}
+	status := fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status))
+	if r.RequestID != "" {
+		fmt.Fprintf(w, "[debug] ← %s (%dms) request-id=%s\n", status, r.DurationMs, r.RequestID)
+	} else {
+		fmt.Fprintf(w, "[debug] ← %s (%dms)\n", status, r.DurationMs)
+	}
+	writeDebugHeaders(w, r.ResponseHeaders)
+	writeDebugBody(w, r.ResponseBody)
+}
+
+func writeDebugHeaders(w io.Writer, headers map[string]string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "[debug]   %s: %s\n", name, headers[name])
+	}
+}
+
+func writeDebugBody(w io.Writer, body json.RawMessage) {
+	if len(body) == 0 {
+		return
+	}
+	text := string(body)
+	var s string
+	if json.Unmarshal(body, &s) == nil {
+		// Non-JSON bodies are stored as JSON strings
+		text = s
+	}
+	if len(text) > maxDebugBody {
+		text = fmt.Sprintf("%s... (%d bytes truncated)", text[:maxDebugBody], len(text)-maxDebugBody)
+	}
+	fmt.Fprintf(w, "[debug]   %s\n", text)
+}
+
+// debugTransport records every request and response that passes through it
+type debugTransport struct {
+	base   http.RoundTripper
+	logger *DebugLogger
+}
+
+// NewDebugTransport wraps base (http.DefaultTransport when nil) so each
+// exchange is written to logger with secrets redacted
+func NewDebugTransport(base http.RoundTripper, logger *DebugLogger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &debugTransport{base: base, logger: logger}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := &DebugRecord{
+		Time:           time.Now().UTC(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		record.RequestBody = redactBody(body)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	record.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		record.Error = err.Error()
+		t.logger.Log(record)
+		return resp, err
+	}
+
+	record.Status = resp.StatusCode
+	record.ResponseHeaders = redactHeaders(resp.Header)
+	for _, name := range requestIDHeaders {
+		if id := resp.Header.Get(name); id != "" {
+			record.RequestID = id
+			break
+		}
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	record.ResponseBody = redactBody(body)
+	if readErr != nil {
+		record.Error = readErr.Error()
+	}
+
+	t.logger.Log(record)
+	return resp, readErr
+}
+
+// isSecretKey reports whether a header, query parameter or JSON field name
+// looks like it holds a credential
+func isSecretKey(name string) bool {
+	key := strings.ToLower(name)
+	key = strings.NewReplacer("-", "", "_", "", " ", "").Replace(key)
+	switch key {
+	case "authorization", "cookie", "setcookie", "key", "auth":
+		return true
+	}
+	for _, suffix := range []string{"apikey", "privatekey", "secretkey", "accesskey", "token", "secret", "password", "credential", "credentials"} {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if isSecretKey(name) {
+			value = redacted
+		}
+		out[name] = value
+	}
+	return out
+}
+
+func redactURL(u *url.URL) string {
+	clone := *u
+	clone.User = nil
+	query := clone.Query()
+	changed := false
+	for name := range query {
+		if isSecretKey(name) {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clone.RawQuery = query.Encode()
+	}
+	return clone.String()
+}
+
+// redactBody returns body as JSON with secret fields replaced. Non-JSON
+// bodies are returned as a JSON string.
+func redactBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err == nil {
+		if out, err := json.Marshal(redactValue(v)); err == nil {
+			return out
+		}
+	}
+	out, _ := json.Marshal(string(body))
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, field := range val {
+			if isSecretKey(key) && field != nil {
+				val[key] = redacted
+				continue
+			}
+			val[key] = redactValue(field)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
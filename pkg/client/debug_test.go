@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"Authorization", true},
+		{"apiKey", true},
+		{"api_key", true},
+		{"X-Api-Key", true},
+		{"secret", true},
+		{"serverUrlSecret", true},
+		{"accessToken", true},
+		{"password", true},
+		{"credentials", true},
+		{"maxTokens", false},
+		{"keywords", false},
+		{"name", false},
+		{"credentialIds", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isSecretKey(tt.name))
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"name":"Support","model":{"maxTokens":250,"apiKey":"sk-live"},"credentials":[{"provider":"openai","apiKey":"sk-2"}],"server":{"url":"https://x","secret":null}}`
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(redactBody([]byte(body)), &got))
+
+	assert.Equal(t, "Support", got["name"])
+	assert.Equal(t, redacted, got["credentials"])
+	model := got["model"].(map[string]interface{})
+	assert.Equal(t, redacted, model["apiKey"])
+	assert.Equal(t, float64(250), model["maxTokens"])
+	assert.Nil(t, got["server"].(map[string]interface{})["secret"])
+
+	assert.Equal(t, `"not json"`, string(redactBody([]byte("not json"))))
+	assert.Nil(t, redactBody(nil))
+}
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"test","apiKey":"sk-live"}`, string(body))
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"a1","token":"t-1"}`))
+	}))
+	defer server.Close()
+
+	console := new(bytes.Buffer)
+	file := new(bytes.Buffer)
+	httpClient := &http.Client{Transport: NewDebugTransport(nil, NewDebugLogger(console, file))}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/assistant?api_key=sk-q", strings.NewReader(`{"name":"test","apiKey":"sk-live"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer sk-header")
+	resp, err := httpClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	// The caller still sees the full, unredacted response
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"a1","token":"t-1"}`, string(respBody))
+
+	for _, out := range []string{console.String(), file.String()} {
+		assert.NotContains(t, out, "sk-live")
+		assert.NotContains(t, out, "sk-header")
+		assert.NotContains(t, out, "sk-q")
+		assert.NotContains(t, out, "t-1")
+	}
+	assert.Contains(t, console.String(), "← 201 Created")
+	assert.Contains(t, console.String(), "request-id=req-1")
+
+	var record DebugRecord
+	require.NoError(t, json.Unmarshal(file.Bytes(), &record))
+	assert.Equal(t, http.MethodPost, record.Method)
+	assert.Equal(t, http.StatusCreated, record.Status)
+	assert.Equal(t, "req-1", record.RequestID)
+	assert.Equal(t, redacted, record.RequestHeaders["Authorization"])
+	assert.JSONEq(t, `{"apiKey":"[REDACTED]","name":"test"}`, string(record.RequestBody))
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsDecodeError reports whether err came from the SDK failing to decode an
+// API response, which usually means Vapi shipped a type or field this CLI
+// version doesn't know yet
+func IsDecodeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var typeErr *json.UnmarshalTypeError
+	return strings.Contains(err.Error(), "cannot be deserialized") || errors.As(err, &typeErr)
+}
+
+// GetRaw fetches path and decodes the response into loosely typed values,
+// bypassing the SDK's models
+func (v *VapiClient) GetRaw(ctx context.Context, path string) (interface{}, error) {
+	resp, err := v.DoRaw(ctx, &RawRequest{Method: http.MethodGet, Path: path})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(resp.Body)))
+	}
+	return decodeRaw(resp.Body)
+}
+
+// RawPages returns a PageFunc that walks a list endpoint through the raw
+// HTTP path. query carries any server-side filters. Both plain arrays and
+// {"results": [...]} envelopes are understood.
+func (v *VapiClient) RawPages(path string, query url.Values) PageFunc[map[string]interface{}] {
+	return func(ctx context.Context, page PageRequest) ([]map[string]interface{}, error) {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = append([]string(nil), values...)
+		}
+		q.Set("limit", strconv.FormatFloat(page.Limit, 'f', -1, 64))
+		for key, t := range map[string]*time.Time{
+			"createdAtGt": page.CreatedAtGt,
+			"createdAtLt": page.CreatedAtLt,
+			"createdAtLe": page.CreatedAtLe,
+		} {
+			if t != nil {
+				q.Set(key, t.UTC().Format(time.RFC3339Nano))
+			}
+		}
+		return v.getRawList(ctx, path, q)
+	}
+}
+
+// RawUnpaged returns a PageFunc for list endpoints that ignore paging
+// parameters. The full list is fetched once and windowed locally.
+func (v *VapiClient) RawUnpaged(path string) PageFunc[map[string]interface{}] {
+	var all []map[string]interface{}
+	fetched := false
+	return func(ctx context.Context, page PageRequest) ([]map[string]interface{}, error) {
+		if !fetched {
+			items, err := v.getRawList(ctx, path, nil)
+			if err != nil {
+				return nil, err
+			}
+			if err := sortNewestFirst(items); err != nil {
+				return nil, err
+			}
+			all = items
+			fetched = true
+		}
+		return windowPage(all, page, func(item map[string]interface{}) time.Time {
+			_, createdAt, _ := ResourceKey(item)
+			return createdAt
+		}), nil
+	}
+}
+
+// ResumeRaw continues a list where it failed, re-issuing the remaining pages
+// with fetch. Items already returned by it are not repeated.
+func ResumeRaw[T any](it *Iterator[T], fetch PageFunc[map[string]interface{}]) *Iterator[map[string]interface{}] {
+	opts := it.opts
+	if opts.Limit > 0 {
+		opts.Limit -= it.count
+	}
+	raw := NewIterator(opts, fetch)
+	raw.opts.PageSize = it.opts.PageSize
+	raw.cursor = it.cursor
+	raw.strict = it.strict
+	for id := range it.seen {
+		raw.seen[id] = true
+	}
+	return raw
+}
+
+func (v *VapiClient) getRawList(ctx context.Context, path string, query url.Values) ([]map[string]interface{}, error) {
+	resp, err := v.DoRaw(ctx, &RawRequest{Method: http.MethodGet, Path: path, Query: query})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("API error %d: %s", resp.StatusCode, strings.TrimSpace(string(resp.Body)))
+	}
+
+	var list []map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &list); err == nil {
+		return list, nil
+	}
+	var envelope struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	if err := json.Unmarshal(resp.Body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse list response: %w", err)
+	}
+	return envelope.Results, nil
+}
+
+func decodeRaw(body []byte) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return v, nil
+}
+
+func sortNewestFirst(items []map[string]interface{}) error {
+	type keyed struct {
+		item      map[string]interface{}
+		createdAt time.Time
+	}
+	sorted := make([]keyed, len(items))
+	for i, item := range items {
+		_, createdAt, err := ResourceKey(item)
+		if err != nil {
+			return err
+		}
+		sorted[i] = keyed{item: item, createdAt: createdAt}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].createdAt.After(sorted[j].createdAt)
+	})
+	for i := range sorted {
+		items[i] = sorted[i].item
+	}
+	return nil
+}
+
+// windowPage returns up to page.Limit items from a newest-first list that
+// fall inside the page's createdAt bounds
+func windowPage[T any](all []T, page PageRequest, createdAt func(T) time.Time) []T {
+	var result []T
+	for _, item := range all {
+		created := createdAt(item)
+		if page.CreatedAtGt != nil && !created.After(*page.CreatedAtGt) {
+			continue
+		}
+		if page.CreatedAtLt != nil && !created.Before(*page.CreatedAtLt) {
+			continue
+		}
+		if page.CreatedAtLe != nil && created.After(*page.CreatedAtLe) {
+			continue
+		}
+		result = append(result, item)
+		if len(result) >= int(page.Limit) {
+			break
+		}
+	}
+	return result
+}
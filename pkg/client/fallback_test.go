@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDecodeError(t *testing.T) {
+	typeErr := &json.UnmarshalTypeError{Value: "number", Field: "id"}
+
+	assert.True(t, IsDecodeError(errors.New(`{"provider":"new"} cannot be deserialized as a *vapi.AssistantModel`)))
+	assert.True(t, IsDecodeError(fmt.Errorf("failed: %w", typeErr)))
+	assert.False(t, IsDecodeError(errors.New("API error 500")))
+	assert.False(t, IsDecodeError(nil))
+}
+
+func TestResumeRaw(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var items []fakeItem
+	for i := 9; i >= 0; i-- {
+		items = append(items, fakeItem{ID: fmt.Sprintf("i%d", i), CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+
+	calls := 0
+	list := fakeList(items, &calls)
+	decodeErr := errors.New("cannot be deserialized")
+
+	// The typed endpoint fails on its second page, as if it held a new type
+	typed := NewIterator(ListOptions{Limit: 8, PageSize: 3}, func(ctx context.Context, page PageRequest) ([]fakeItem, error) {
+		if page.CreatedAtLe != nil {
+			return nil, decodeErr
+		}
+		return list(ctx, page)
+	})
+
+	var ids []string
+	for typed.Next(context.Background()) {
+		ids = append(ids, typed.Item().ID)
+	}
+	require.ErrorIs(t, typed.Err(), decodeErr)
+
+	raw := ResumeRaw(typed, func(ctx context.Context, page PageRequest) ([]map[string]interface{}, error) {
+		page0, err := list(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]map[string]interface{}, len(page0))
+		for i, item := range page0 {
+			out[i] = map[string]interface{}{"id": item.ID, "createdAt": item.CreatedAt.Format(time.RFC3339)}
+		}
+		return out, nil
+	})
+	for raw.Next(context.Background()) {
+		ids = append(ids, raw.Item()["id"].(string))
+	}
+	require.NoError(t, raw.Err())
+
+	assert.Equal(t, []string{"i9", "i8", "i7", "i6", "i5", "i4", "i3", "i2"}, ids)
+}
+
+func TestWindowPage(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	all := []time.Time{base.Add(3 * time.Hour), base.Add(2 * time.Hour), base.Add(time.Hour), base}
+	le := base.Add(2 * time.Hour)
+
+	page := windowPage(all, PageRequest{Limit: 2, CreatedAtLe: &le}, func(t time.Time) time.Time { return t })
+	assert.Equal(t, []time.Time{base.Add(2 * time.Hour), base.Add(time.Hour)}, page)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryLimit is how many revisions are kept per resource
+const HistoryLimit = 50
+
+// Revision is the state of a resource just before it was updated
+type Revision struct {
+	Number  int                    `json:"revision"`
+	SavedAt time.Time              `json:"savedAt"`
+	Data    map[string]interface{} `json:"data"`
+}
+
+// HistoryStore keeps local revisions of updated resources, one directory
+// per account, kind and resource ID
+type HistoryStore struct {
+	dir string
+	now func() time.Time
+}
+
+// NewHistoryStore returns a store rooted at dir. An empty dir disables
+// history.
+func NewHistoryStore(dir string) *HistoryStore {
+	return &HistoryStore{dir: dir, now: time.Now}
+}
+
+// DefaultHistoryDir returns the history directory under the user's config
+// directory
+func DefaultHistoryDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "vapi-cli", "history")
+}
+
+// Save records data as the next revision of the resource and drops
+// revisions beyond HistoryLimit
+func (s *HistoryStore) Save(scope string, kind ResourceKind, id string, data map[string]interface{}) (*Revision, error) {
+	if s == nil || s.dir == "" {
+		return nil, nil
+	}
+	revisions, err := s.List(scope, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rev := &Revision{Number: 1, SavedAt: s.now().UTC(), Data: data}
+	if len(revisions) > 0 {
+		rev.Number = revisions[len(revisions)-1].Number + 1
+	}
+
+	dir := s.resourceDir(scope, kind, id)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	encoded, err := json.MarshalIndent(rev, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode revision: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, strconv.Itoa(rev.Number)+".json"), encoded, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save revision: %w", err)
+	}
+
+	for _, old := range revisions[:max(len(revisions)+1-HistoryLimit, 0)] {
+		_ = os.Remove(filepath.Join(dir, strconv.Itoa(old.Number)+".json"))
+	}
+	return rev, nil
+}
+
+// List returns the saved revisions of a resource, oldest first
+func (s *HistoryStore) List(scope string, kind ResourceKind, id string) ([]Revision, error) {
+	if s == nil || s.dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(s.resourceDir(scope, kind, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var revisions []Revision
+	for _, entry := range entries {
+		n, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		rev, err := s.Get(scope, kind, id, n)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *rev)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Number < revisions[j].Number })
+	return revisions, nil
+}
+
+// Get returns revision n of a resource
+func (s *HistoryStore) Get(scope string, kind ResourceKind, id string, n int) (*Revision, error) {
+	if s == nil || s.dir == "" {
+		return nil, fmt.Errorf("history is disabled")
+	}
+	data, err := os.ReadFile(filepath.Join(s.resourceDir(scope, kind, id), strconv.Itoa(n)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s %s has no revision %d", kind.Name, id, n)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revision %d: %w", n, err)
+	}
+	var rev Revision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		return nil, fmt.Errorf("failed to parse revision %d: %w", n, err)
+	}
+	return &rev, nil
+}
+
+func (s *HistoryStore) resourceDir(scope string, kind ResourceKind, id string) string {
+	return filepath.Join(s.dir, scope, strings.Trim(kind.Path, "/"), filepath.Base(id))
+}
+
+// History returns the local revisions of the resource of kind with id in
+// this client's account
+func (v *VapiClient) History(kind ResourceKind, id string) ([]Revision, error) {
+	return v.history.List(v.AccountFingerprint(), kind, id)
+}
+
+// Revision returns revision n of the resource of kind with id
+func (v *VapiClient) Revision(kind ResourceKind, id string, n int) (*Revision, error) {
+	return v.history.Get(v.AccountFingerprint(), kind, id, n)
+}
+
+// saveRevision records the current state of a resource before it changes.
+// A resource that doesn't exist has nothing to record.
+func (v *VapiClient) saveRevision(ctx context.Context, kind ResourceKind, id string) error {
+	if v.history == nil || v.history.dir == "" {
+		return nil
+	}
+	current, err := v.FetchRaw(ctx, kind, id)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s before updating it: %w", kind.Name, err)
+	}
+	if _, err := v.history.Save(v.AccountFingerprint(), kind, id, current); err != nil {
+		return fmt.Errorf("failed to record %s history: %w", kind.Name, err)
+	}
+	return nil
+}
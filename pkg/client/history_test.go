@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/config"
+)
+
+func TestUpdateRawRecordsHistory(t *testing.T) {
+	current := map[string]interface{}{"id": "a1", "name": "support", "firstMessage": "Hi"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/assistant/a1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(current)
+		case r.URL.Path == "/assistant/a1" && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			var patch map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &patch))
+			for k, v := range patch {
+				current[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(current)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	v := &VapiClient{
+		config:     &config.Config{APIKey: "sk-test", BaseURL: server.URL},
+		httpClient: server.Client(),
+		history:    NewHistoryStore(filepath.Join(t.TempDir(), "history")),
+		apiKey:     "sk-test",
+	}
+	ctx := context.Background()
+
+	_, err := v.UpdateRaw(ctx, Assistants, "a1", map[string]interface{}{"firstMessage": "Hello"})
+	require.NoError(t, err)
+	_, err = v.UpdateRaw(ctx, Assistants, "a1", map[string]interface{}{"firstMessage": "Hey"})
+	require.NoError(t, err)
+
+	revisions, err := v.History(Assistants, "a1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Number)
+	assert.Equal(t, "Hi", revisions[0].Data["firstMessage"])
+	assert.Equal(t, "Hello", revisions[1].Data["firstMessage"])
+
+	rev, err := v.Revision(Assistants, "a1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", rev.Data["firstMessage"])
+
+	_, err = v.Revision(Assistants, "a1", 9)
+	assert.ErrorContains(t, err, "has no revision 9")
+
+	// Another account sees its own history
+	other := *v
+	other.apiKey = "sk-other"
+	revisions, err = other.History(Assistants, "a1")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestHistoryLimit(t *testing.T) {
+	store := NewHistoryStore(t.TempDir())
+	for i := 0; i < HistoryLimit+3; i++ {
+		_, err := store.Save("scope", Tools, "t1", map[string]interface{}{"n": i})
+		require.NoError(t, err)
+	}
+	revisions, err := store.List("scope", Tools, "t1")
+	require.NoError(t, err)
+	require.Len(t, revisions, HistoryLimit)
+	assert.Equal(t, 4, revisions[0].Number)
+	assert.Equal(t, HistoryLimit+3, revisions[len(revisions)-1].Number)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	vapi "github.com/VapiAI/server-sdk-go"
+)
+
+// ListAssistants returns an iterator over the account's assistants
+func (v *VapiClient) ListAssistants(opts ListOptions) *Iterator[*vapi.Assistant] {
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.Assistant, error) {
+		return v.client.Assistants.List(ctx, &vapi.AssistantsListRequest{
+			Limit:       vapi.Float64(page.Limit),
+			CreatedAtGt: page.CreatedAtGt,
+			CreatedAtLt: page.CreatedAtLt,
+			CreatedAtLe: page.CreatedAtLe,
+		})
+	})
+}
+
+// ListCalls returns an iterator over calls. filter may carry server-side
+// filters such as AssistantId; its paging fields are overwritten.
+func (v *VapiClient) ListCalls(opts ListOptions, filter *vapi.CallsListRequest) *Iterator[*vapi.Call] {
+	base := vapi.CallsListRequest{}
+	if filter != nil {
+		base = *filter
+	}
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.Call, error) {
+		req := base
+		req.Limit = vapi.Float64(page.Limit)
+		req.CreatedAtGt = page.CreatedAtGt
+		req.CreatedAtLt = page.CreatedAtLt
+		req.CreatedAtLe = page.CreatedAtLe
+		return v.client.Calls.List(ctx, &req)
+	})
+}
+
+// ListChats returns an iterator over chat conversations
+func (v *VapiClient) ListChats(opts ListOptions) *Iterator[*vapi.Chat] {
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.Chat, error) {
+		resp, err := v.client.Chats.List(ctx, &vapi.ChatsListRequest{
+			Limit:       vapi.Float64(page.Limit),
+			CreatedAtGt: page.CreatedAtGt,
+			CreatedAtLt: page.CreatedAtLt,
+			CreatedAtLe: page.CreatedAtLe,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Results, nil
+	})
+}
+
+// ListPhoneNumbers returns an iterator over phone numbers
+func (v *VapiClient) ListPhoneNumbers(opts ListOptions) *Iterator[*vapi.PhoneNumbersListResponseItem] {
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.PhoneNumbersListResponseItem, error) {
+		return v.client.PhoneNumbers.List(ctx, &vapi.PhoneNumbersListRequest{
+			Limit:       vapi.Float64(page.Limit),
+			CreatedAtGt: page.CreatedAtGt,
+			CreatedAtLt: page.CreatedAtLt,
+			CreatedAtLe: page.CreatedAtLe,
+		})
+	})
+}
+
+// ListTools returns an iterator over tools
+func (v *VapiClient) ListTools(opts ListOptions) *Iterator[*vapi.ToolsListResponseItem] {
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.ToolsListResponseItem, error) {
+		return v.client.Tools.List(ctx, &vapi.ToolsListRequest{
+			Limit:       vapi.Float64(page.Limit),
+			CreatedAtGt: page.CreatedAtGt,
+			CreatedAtLt: page.CreatedAtLt,
+			CreatedAtLe: page.CreatedAtLe,
+		})
+	})
+}
+
+// ListCampaigns returns an iterator over campaigns
+func (v *VapiClient) ListCampaigns(opts ListOptions) *Iterator[*vapi.Campaign] {
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.Campaign, error) {
+		resp, err := v.client.Campaigns.CampaignControllerFindAll(ctx, &vapi.CampaignControllerFindAllRequest{
+			Limit:       vapi.Float64(page.Limit),
+			CreatedAtGt: page.CreatedAtGt,
+			CreatedAtLt: page.CreatedAtLt,
+			CreatedAtLe: page.CreatedAtLe,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Results, nil
+	})
+}
+
+// ListWorkflows returns an iterator over workflows. The workflow endpoint
+// does not page, so the full list is fetched once and windowed locally.
+func (v *VapiClient) ListWorkflows(opts ListOptions) *Iterator[*vapi.Workflow] {
+	var all []*vapi.Workflow
+	fetched := false
+	return NewIterator(opts, func(ctx context.Context, page PageRequest) ([]*vapi.Workflow, error) {
+		if !fetched {
+			workflows, err := v.client.Workflow.WorkflowControllerFindAll(ctx)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(workflows, func(i, j int) bool {
+				return workflows[i].CreatedAt.After(workflows[j].CreatedAt)
+			})
+			all = workflows
+			fetched = true
+		}
+
+		return windowPage(all, page, func(w *vapi.Workflow) time.Time { return w.CreatedAt }), nil
+	})
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NameCacheTTL is how long a resolved name→ID lookup is trusted
+const NameCacheTTL = 5 * time.Minute
+
+// NameCache persists name→ID lookups on disk so repeated commands don't
+// have to list every resource again. It is best effort: any read or write
+// failure behaves like a cache miss.
+type NameCache struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+type nameCacheEntry struct {
+	ID      string    `json:"id"`
+	Expires time.Time `json:"expires"`
+}
+
+// NewNameCache returns a cache stored at path. An empty path disables
+// caching.
+func NewNameCache(path string) *NameCache {
+	return &NameCache{path: path, ttl: NameCacheTTL, now: time.Now}
+}
+
+// DefaultNameCachePath returns the cache file under the user's cache
+// directory
+func DefaultNameCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "vapi-cli", "names.json")
+}
+
+// Get returns the cached ID for ref if it hasn't expired
+func (c *NameCache) Get(scope string, kind ResourceKind, ref string) (string, bool) {
+	if c == nil || c.path == "" {
+		return "", false
+	}
+	entry, ok := c.load()[cacheKey(scope, kind, ref)]
+	if !ok || !c.now().Before(entry.Expires) {
+		return "", false
+	}
+	return entry.ID, true
+}
+
+// Put records that ref resolved to id
+func (c *NameCache) Put(scope string, kind ResourceKind, ref, id string) {
+	if c == nil || c.path == "" {
+		return
+	}
+	entries := c.load()
+	entries[cacheKey(scope, kind, ref)] = nameCacheEntry{ID: id, Expires: c.now().Add(c.ttl)}
+	c.save(entries)
+}
+
+// Forget drops every cached lookup for kind within scope
+func (c *NameCache) Forget(scope string, kind ResourceKind) {
+	if c == nil || c.path == "" {
+		return
+	}
+	entries := c.load()
+	prefix := cacheKey(scope, kind, "")
+	for key := range entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(entries, key)
+		}
+	}
+	c.save(entries)
+}
+
+// load reads the cache file, dropping expired entries
+func (c *NameCache) load() map[string]nameCacheEntry {
+	entries := make(map[string]nameCacheEntry)
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return entries
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return make(map[string]nameCacheEntry)
+	}
+	now := c.now()
+	for key, entry := range entries {
+		if !now.Before(entry.Expires) {
+			delete(entries, key)
+		}
+	}
+	return entries
+}
+
+// save writes entries atomically so concurrent commands never read a
+// partial file
+func (c *NameCache) save(entries map[string]nameCacheEntry) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".names-*.json")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func cacheKey(scope string, kind ResourceKind, ref string) string {
+	return scope + "|" + kind.Path + "|" + ref
+}
+
+// AccountFingerprint identifies the client's account and environment
+// without revealing the API key. It is safe to use as a file name.
+func (v *VapiClient) AccountFingerprint() string {
+	sum := sha256.Sum256([]byte(v.config.GetAPIBaseURL() + "|" + v.apiKey))
+	return hex.EncodeToString(sum[:8])
+}
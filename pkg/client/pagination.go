@@ -0,0 +1,198 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultPageSize is the number of items requested per page
+const DefaultPageSize = 100
+
+// ListOptions controls how many items a list iterator returns and which
+// createdAt window it walks
+type ListOptions struct {
+	Limit         int // Maximum number of items to return, 0 for no limit
+	PageSize      int // Items requested per API call
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// PageRequest describes one page to fetch. Vapi list endpoints return the
+// newest items first, so each page after the first is bounded by the oldest
+// createdAt seen so far.
+type PageRequest struct {
+	Limit       float64
+	CreatedAtGt *time.Time // Lower bound from --created-after
+	CreatedAtLt *time.Time // Exclusive upper bound
+	CreatedAtLe *time.Time // Inclusive upper bound used to resume at a cursor
+}
+
+// PageFunc fetches a single page of items
+type PageFunc[T any] func(ctx context.Context, page PageRequest) ([]T, error)
+
+// Iterator walks a list endpoint page by page using createdAt cursors, so
+// only one page is held in memory at a time.
+//
+//	it := client.NewIterator(opts, fetch)
+//	for it.Next(ctx) {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	opts  ListOptions
+	fetch PageFunc[T]
+
+	buf       []T
+	item      T
+	err       error
+	count     int
+	exhausted bool
+
+	// cursor is the oldest createdAt returned so far. seen holds the IDs
+	// already returned with exactly that timestamp so resuming with an
+	// inclusive bound does not repeat them.
+	cursor *time.Time
+	seen   map[string]bool
+	strict bool
+}
+
+// NewIterator creates an iterator over fetch
+func NewIterator[T any](opts ListOptions, fetch PageFunc[T]) *Iterator[T] {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Limit > 0 && opts.Limit < opts.PageSize {
+		opts.PageSize = opts.Limit
+	}
+	return &Iterator[T]{
+		opts:  opts,
+		fetch: fetch,
+		seen:  make(map[string]bool),
+	}
+}
+
+// Next advances to the next item, fetching another page when needed
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.opts.Limit > 0 && it.count >= it.opts.Limit {
+		return false
+	}
+
+	for len(it.buf) == 0 {
+		if it.exhausted {
+			return false
+		}
+		if err := it.fetchPage(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.item = it.buf[0]
+	it.buf = it.buf[1:]
+	it.count++
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the first error encountered while fetching pages
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Count returns the number of items returned so far
+func (it *Iterator[T]) Count() int {
+	return it.count
+}
+
+func (it *Iterator[T]) fetchPage(ctx context.Context) error {
+	req := PageRequest{
+		Limit:       float64(it.opts.PageSize),
+		CreatedAtGt: it.opts.CreatedAfter,
+	}
+	switch {
+	case it.cursor == nil:
+		req.CreatedAtLt = it.opts.CreatedBefore
+	case it.strict:
+		req.CreatedAtLt = it.cursor
+	default:
+		req.CreatedAtLe = it.cursor
+	}
+
+	page, err := it.fetch(ctx, req)
+	if err != nil {
+		return err
+	}
+	if len(page) < it.opts.PageSize {
+		it.exhausted = true
+	}
+
+	fresh := make([]T, 0, len(page))
+	for _, item := range page {
+		id, createdAt, err := ResourceKey(item)
+		if err != nil {
+			return err
+		}
+		if it.cursor != nil && createdAt.Equal(*it.cursor) && it.seen[id] {
+			continue
+		}
+		if it.cursor == nil || createdAt.Before(*it.cursor) {
+			ts := createdAt
+			it.cursor = &ts
+			it.seen = make(map[string]bool)
+		}
+		if createdAt.Equal(*it.cursor) {
+			it.seen[id] = true
+		}
+		fresh = append(fresh, item)
+	}
+
+	// A full page of already-seen items means more items share the cursor
+	// timestamp than fit in one page. Step strictly past it to make progress.
+	it.strict = len(fresh) == 0 && !it.exhausted
+	it.buf = fresh
+	return nil
+}
+
+// ResourceKey extracts the id and createdAt fields from any API resource,
+// including the SDK's union types, by way of its JSON encoding
+func ResourceKey(v interface{}) (string, time.Time, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode resource: %w", err)
+	}
+	var key struct {
+		ID        string    `json:"id"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read resource id: %w", err)
+	}
+	return key.ID, key.CreatedAt, nil
+}
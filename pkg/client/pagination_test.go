@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeItem struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// fakeList serves items newest first, honoring the page window like the API
+func fakeList(items []fakeItem, calls *int) PageFunc[fakeItem] {
+	return func(ctx context.Context, page PageRequest) ([]fakeItem, error) {
+		*calls++
+		var out []fakeItem
+		for _, item := range items {
+			if page.CreatedAtGt != nil && !item.CreatedAt.After(*page.CreatedAtGt) {
+				continue
+			}
+			if page.CreatedAtLt != nil && !item.CreatedAt.Before(*page.CreatedAtLt) {
+				continue
+			}
+			if page.CreatedAtLe != nil && item.CreatedAt.After(*page.CreatedAtLe) {
+				continue
+			}
+			out = append(out, item)
+			if len(out) == int(page.Limit) {
+				break
+			}
+		}
+		return out, nil
+	}
+}
+
+func collect(t *testing.T, it *Iterator[fakeItem]) []string {
+	t.Helper()
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Item().ID)
+	}
+	require.NoError(t, it.Err())
+	return ids
+}
+
+func TestIterator(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var items []fakeItem
+	for i := 9; i >= 0; i-- {
+		items = append(items, fakeItem{ID: fmt.Sprintf("i%d", i), CreatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+
+	t.Run("walks every page", func(t *testing.T) {
+		calls := 0
+		ids := collect(t, NewIterator(ListOptions{PageSize: 3}, fakeList(items, &calls)))
+		assert.Equal(t, []string{"i9", "i8", "i7", "i6", "i5", "i4", "i3", "i2", "i1", "i0"}, ids)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("stops at limit", func(t *testing.T) {
+		calls := 0
+		ids := collect(t, NewIterator(ListOptions{Limit: 4, PageSize: 3}, fakeList(items, &calls)))
+		assert.Equal(t, []string{"i9", "i8", "i7", "i6"}, ids)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("applies created window", func(t *testing.T) {
+		calls := 0
+		after := base.Add(2 * time.Hour)
+		before := base.Add(6 * time.Hour)
+		opts := ListOptions{PageSize: 2, CreatedAfter: &after, CreatedBefore: &before}
+		ids := collect(t, NewIterator(opts, fakeList(items, &calls)))
+		assert.Equal(t, []string{"i5", "i4", "i3"}, ids)
+	})
+
+	t.Run("does not skip items sharing a timestamp", func(t *testing.T) {
+		same := []fakeItem{
+			{ID: "a", CreatedAt: base.Add(2 * time.Hour)},
+			{ID: "b", CreatedAt: base.Add(time.Hour)},
+			{ID: "c", CreatedAt: base},
+			{ID: "d", CreatedAt: base},
+			{ID: "e", CreatedAt: base},
+		}
+		calls := 0
+		ids := collect(t, NewIterator(ListOptions{PageSize: 3}, fakeList(same, &calls)))
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids)
+	})
+
+	t.Run("makes progress when a page is all one timestamp", func(t *testing.T) {
+		same := []fakeItem{
+			{ID: "a", CreatedAt: base.Add(time.Hour)},
+			{ID: "b", CreatedAt: base.Add(time.Hour)},
+			{ID: "c", CreatedAt: base},
+		}
+		calls := 0
+		ids := collect(t, NewIterator(ListOptions{PageSize: 2}, fakeList(same, &calls)))
+		assert.Equal(t, []string{"a", "b", "c"}, ids)
+	})
+
+	t.Run("surfaces fetch errors", func(t *testing.T) {
+		it := NewIterator(ListOptions{}, func(ctx context.Context, page PageRequest) ([]fakeItem, error) {
+			return nil, errors.New("boom")
+		})
+		assert.False(t, it.Next(context.Background()))
+		assert.EqualError(t, it.Err(), "boom")
+	})
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// namePrefix marks a reference as a name or glob rather than an ID
+const namePrefix = "name:"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ResourceKind describes a resource type that can be referenced by name
+type ResourceKind struct {
+	Name       string   // Singular noun used in messages, e.g. "assistant"
+	Path       string   // List endpoint, e.g. "/assistant"
+	NameFields []string // Dotted JSON paths a name reference is matched against
+	Unpaged    bool     // The list endpoint ignores paging parameters
+	ReadOnly   []string // Fields returned besides ServerFields that can't be written
+}
+
+// Resource kinds that accept names wherever an ID is expected
+var (
+	Assistants   = ResourceKind{Name: "assistant", Path: "/assistant", NameFields: []string{"name"}, ReadOnly: []string{"isServerUrlSecretSet"}}
+	Tools        = ResourceKind{Name: "tool", Path: "/tool", NameFields: []string{"function.name", "name"}}
+	PhoneNumbers = ResourceKind{Name: "phone number", Path: "/phone-number", NameFields: []string{"name", "number", "sipUri"}}
+	Workflows    = ResourceKind{Name: "workflow", Path: "/workflow", NameFields: []string{"name"}, Unpaged: true}
+	Campaigns    = ResourceKind{Name: "campaign", Path: "/campaign", NameFields: []string{"name"}}
+	Squads       = ResourceKind{Name: "squad", Path: "/squad", NameFields: []string{"name"}}
+	Credentials  = ResourceKind{Name: "credential", Path: "/credential", NameFields: []string{"name"}}
+)
+
+// Candidate is one resource matched by a name reference
+type Candidate struct {
+	ID   string
+	Name string
+}
+
+// AmbiguousError is returned when a name reference matches more than one
+// resource
+type AmbiguousError struct {
+	Kind       ResourceKind
+	Ref        string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d %ss; use an ID or a more specific name:", e.Ref, len(e.Candidates), e.Kind.Name)
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, "\n  %s  %s", c.ID, c.Name)
+	}
+	return b.String()
+}
+
+// IsID reports whether ref looks like a Vapi resource ID
+func IsID(ref string) bool {
+	return uuidPattern.MatchString(ref)
+}
+
+// Resolve turns ref into a resource ID. ref may be an ID, an exact name, or a
+// "name:<glob>" selector where * and ? match any run of characters or any
+// single character. Name lookups are cached briefly per account and
+// environment.
+func (v *VapiClient) Resolve(ctx context.Context, kind ResourceKind, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("%s ID or name is required", kind.Name)
+	}
+	if IsID(ref) {
+		return ref, nil
+	}
+
+	scope := v.AccountFingerprint()
+	if id, ok := v.names.Get(scope, kind, ref); ok {
+		return id, nil
+	}
+
+	match, err := nameMatcher(ref)
+	if err != nil {
+		return "", err
+	}
+	items, err := v.ListAllRaw(ctx, kind)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s %q: %w", kind.Name, ref, err)
+	}
+
+	var candidates []Candidate
+	for _, item := range items {
+		id, _ := item["id"].(string)
+		for _, field := range kind.NameFields {
+			if name := LookupString(item, field); name != "" && match(name) {
+				candidates = append(candidates, Candidate{ID: id, Name: name})
+				break
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no %s found matching %q", kind.Name, ref)
+	case 1:
+		v.names.Put(scope, kind, ref, candidates[0].ID)
+		return candidates[0].ID, nil
+	default:
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+		return "", &AmbiguousError{Kind: kind, Ref: ref, Candidates: candidates}
+	}
+}
+
+// ForgetNames drops cached name lookups for kind, e.g. after a delete or
+// rename
+func (v *VapiClient) ForgetNames(kind ResourceKind) {
+	v.names.Forget(v.AccountFingerprint(), kind)
+}
+
+// ListAllRaw fetches every resource of kind through the raw HTTP path
+func (v *VapiClient) ListAllRaw(ctx context.Context, kind ResourceKind) ([]map[string]interface{}, error) {
+	fetch := v.RawPages(kind.Path, nil)
+	if kind.Unpaged {
+		fetch = v.RawUnpaged(kind.Path)
+	}
+	it := NewIterator(ListOptions{}, fetch)
+	var items []map[string]interface{}
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// nameMatcher returns a predicate for ref. Plain references must match a
+// name exactly; "name:" selectors may use * and ? wildcards.
+func nameMatcher(ref string) (func(string) bool, error) {
+	if !strings.HasPrefix(ref, namePrefix) {
+		return func(name string) bool { return name == ref }, nil
+	}
+
+	glob := strings.TrimPrefix(ref, namePrefix)
+	if glob == "" {
+		return nil, fmt.Errorf("empty name selector %q", ref)
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			pattern.WriteString(".*")
+		case '?':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	pattern.WriteString("$")
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid name selector %q: %w", ref, err)
+	}
+	return re.MatchString, nil
+}
+
+// NameOf returns the first non-empty name field of a loosely typed resource
+func (k ResourceKind) NameOf(item map[string]interface{}) string {
+	for _, field := range k.NameFields {
+		if name := LookupString(item, field); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// LookupString reads a dotted path of string keys from a loosely typed
+// resource
+func LookupString(item map[string]interface{}, path string) string {
+	var cur interface{} = item
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		cur = m[key]
+	}
+	s, _ := cur.(string)
+	return s
+}
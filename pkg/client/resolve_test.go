@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/config"
+)
+
+func newResolveTestClient(t *testing.T, body string, calls *int) *VapiClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		assert.Equal(t, "/assistant", r.URL.Path)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return &VapiClient{
+		config:     &config.Config{APIKey: "sk-test", BaseURL: server.URL},
+		httpClient: server.Client(),
+		names:      NewNameCache(filepath.Join(t.TempDir(), "names.json")),
+		apiKey:     "sk-test",
+	}
+}
+
+func TestResolve(t *testing.T) {
+	body := `[
+		{"id": "a1", "name": "Support", "createdAt": "2025-01-03T00:00:00Z"},
+		{"id": "a2", "name": "Sales EU", "createdAt": "2025-01-02T00:00:00Z"},
+		{"id": "a3", "name": "Sales US", "createdAt": "2025-01-01T00:00:00Z"}
+	]`
+	ctx := context.Background()
+
+	t.Run("ids pass through", func(t *testing.T) {
+		calls := 0
+		v := newResolveTestClient(t, body, &calls)
+		id, err := v.Resolve(ctx, Assistants, "0b4a6b67-3152-4b7f-9d1e-2f2d8b1c3a11")
+		require.NoError(t, err)
+		assert.Equal(t, "0b4a6b67-3152-4b7f-9d1e-2f2d8b1c3a11", id)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("exact name is cached", func(t *testing.T) {
+		calls := 0
+		v := newResolveTestClient(t, body, &calls)
+		for i := 0; i < 2; i++ {
+			id, err := v.Resolve(ctx, Assistants, "Support")
+			require.NoError(t, err)
+			assert.Equal(t, "a1", id)
+		}
+		assert.Equal(t, 1, calls)
+
+		v.ForgetNames(Assistants)
+		_, err := v.Resolve(ctx, Assistants, "Support")
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("glob selector", func(t *testing.T) {
+		calls := 0
+		v := newResolveTestClient(t, body, &calls)
+		id, err := v.Resolve(ctx, Assistants, "name:*EU")
+		require.NoError(t, err)
+		assert.Equal(t, "a2", id)
+	})
+
+	t.Run("ambiguous match lists candidates", func(t *testing.T) {
+		calls := 0
+		v := newResolveTestClient(t, body, &calls)
+		_, err := v.Resolve(ctx, Assistants, "name:Sales*")
+		var ambiguous *AmbiguousError
+		require.ErrorAs(t, err, &ambiguous)
+		assert.Equal(t, []Candidate{{ID: "a2", Name: "Sales EU"}, {ID: "a3", Name: "Sales US"}}, ambiguous.Candidates)
+		assert.Contains(t, err.Error(), "a3  Sales US")
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		calls := 0
+		v := newResolveTestClient(t, body, &calls)
+		_, err := v.Resolve(ctx, Assistants, "Sales")
+		assert.ErrorContains(t, err, `no assistant found matching "Sales"`)
+	})
+}
+
+func TestNameCacheExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewNameCache(filepath.Join(t.TempDir(), "names.json"))
+	cache.now = func() time.Time { return now }
+
+	cache.Put("scope", Tools, "lookup", "t1")
+	id, ok := cache.Get("scope", Tools, "lookup")
+	assert.True(t, ok)
+	assert.Equal(t, "t1", id)
+
+	_, ok = cache.Get("other", Tools, "lookup")
+	assert.False(t, ok)
+
+	now = now.Add(NameCacheTTL)
+	_, ok = cache.Get("scope", Tools, "lookup")
+	assert.False(t, ok)
+}
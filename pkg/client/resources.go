@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ServerFields are managed by the API. They are returned on every resource
+// but never accepted in a create or update.
+var ServerFields = []string{"id", "orgId", "createdAt", "updatedAt"}
+
+// StripServerFields returns a shallow copy of item without ServerFields
+func StripServerFields(item map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		out[k] = v
+	}
+	for _, field := range ServerFields {
+		delete(out, field)
+	}
+	return out
+}
+
+// Editable returns a shallow copy of item with only the fields that can be
+// sent back in a create or update
+func (k ResourceKind) Editable(item map[string]interface{}) map[string]interface{} {
+	out := StripServerFields(item)
+	for _, field := range k.ReadOnly {
+		delete(out, field)
+	}
+	return out
+}
+
+// FetchRaw gets the resource of kind with id as loosely typed JSON
+func (v *VapiClient) FetchRaw(ctx context.Context, kind ResourceKind, id string) (map[string]interface{}, error) {
+	return v.DoRawJSON(ctx, http.MethodGet, kind.Path+"/"+id, nil)
+}
+
+// CreateRaw creates a resource of kind from a loosely typed payload, so
+// fields the SDK doesn't model yet survive the round trip
+func (v *VapiClient) CreateRaw(ctx context.Context, kind ResourceKind, body map[string]interface{}) (map[string]interface{}, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", kind.Name, err)
+	}
+	defer v.ForgetNames(kind)
+	return v.DoRawJSON(ctx, http.MethodPost, kind.Path, payload)
+}
+
+// UpdateRaw patches the resource of kind with id. The resource's previous
+// state is saved to the local history first so the update can be rolled
+// back.
+func (v *VapiClient) UpdateRaw(ctx context.Context, kind ResourceKind, id string, body map[string]interface{}) (map[string]interface{}, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", kind.Name, err)
+	}
+	if err := v.saveRevision(ctx, kind, id); err != nil {
+		return nil, err
+	}
+	defer v.ForgetNames(kind)
+	return v.DoRawJSON(ctx, http.MethodPatch, kind.Path+"/"+id, payload)
+}
+
+// DeleteRaw deletes the resource of kind with id
+func (v *VapiClient) DeleteRaw(ctx context.Context, kind ResourceKind, id string) error {
+	defer v.ForgetNames(kind)
+	_, err := v.DoRawJSON(ctx, http.MethodDelete, kind.Path+"/"+id, nil)
+	return err
+}
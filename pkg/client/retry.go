@@ -0,0 +1,203 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VapiAI/cli/pkg/config"
+)
+
+// RetryPolicy controls how failed API requests are retried
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt, 0 disables retrying
+	BaseDelay  time.Duration // Backoff before the first retry, doubled each time
+	MaxDelay   time.Duration // Upper bound for backoff and Retry-After waits
+	AllMethods bool          // Also retry non-idempotent methods such as POST and PATCH
+}
+
+// DefaultRetryPolicy returns the policy used when nothing is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// RetryPolicyFromConfig builds a retry policy from the CLI configuration
+func RetryPolicyFromConfig(cfg *config.Config) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	if cfg == nil {
+		return policy
+	}
+	if cfg.MaxRetries >= 0 {
+		policy.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.RetryMaxDelay > 0 {
+		policy.MaxDelay = time.Duration(cfg.RetryMaxDelay) * time.Second
+	}
+	policy.AllMethods = cfg.RetryAllMethods
+	return policy
+}
+
+// retryTransport is an http.RoundTripper that retries rate-limited and
+// transient failures with exponential backoff and jitter
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport wraps base (http.DefaultTransport when nil) with policy
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{base: base, policy: policy, sleep: sleepContext}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// A RoundTripper must not modify the caller's request, so retries
+		// send a copy with the body replayed from GetBody
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(req.Context())
+			if req.Body != nil && req.Body != http.NoBody {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				// Give up rather than block longer than the configured maximum
+				if wait > t.policy.MaxDelay {
+					return resp, err
+				}
+				delay = wait
+			}
+			// Drain so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if sleepErr := t.sleep(req.Context(), delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+}
+
+// shouldRetry decides whether a request is worth another attempt
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	// A 429 means the request was rejected before it was processed, so
+	// retrying is safe for every method
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !t.policy.AllMethods && !isIdempotent(req.Method) {
+		return false
+	}
+
+	if err != nil {
+		// Never retry once the caller has given up
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns an exponentially growing delay with equal jitter
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.BaseDelay << attempt
+	if delay <= 0 || delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half) // #nosec G404 - jitter does not need crypto randomness
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		wait := time.Until(when)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryClient(policy RetryPolicy, slept *[]time.Duration) *http.Client {
+	transport := NewRetryTransport(nil, policy).(*retryTransport)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		return nil
+	}
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		retryAfter   string
+		allMethods   bool
+		expectStatus int
+		expectCalls  int32
+	}{
+		{name: "429 is retried", method: http.MethodGet, statuses: []int{429, 429, 200}, expectStatus: 200, expectCalls: 3},
+		{name: "429 is retried for POST", method: http.MethodPost, statuses: []int{429, 200}, expectStatus: 200, expectCalls: 2},
+		{name: "503 is retried for GET", method: http.MethodGet, statuses: []int{503, 200}, expectStatus: 200, expectCalls: 2},
+		{name: "503 is not retried for POST", method: http.MethodPost, statuses: []int{503, 200}, expectStatus: 503, expectCalls: 1},
+		{name: "503 is retried for POST with all methods", method: http.MethodPost, statuses: []int{503, 200}, allMethods: true, expectStatus: 200, expectCalls: 2},
+		{name: "400 is not retried", method: http.MethodGet, statuses: []int{400, 200}, expectStatus: 400, expectCalls: 1},
+		{name: "gives up after max retries", method: http.MethodGet, statuses: []int{500, 500, 500, 500, 500}, expectStatus: 500, expectCalls: 4},
+		{name: "gives up when Retry-After exceeds max delay", method: http.MethodGet, statuses: []int{429, 200}, retryAfter: "120", expectStatus: 429, expectCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost {
+					assert.Equal(t, `{"name":"test"}`, string(body))
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer server.Close()
+
+			policy := DefaultRetryPolicy()
+			policy.AllMethods = tt.allMethods
+			var slept []time.Duration
+			httpClient := newTestRetryClient(policy, &slept)
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(`{"name":"test"}`))
+			require.NoError(t, err)
+			resp, err := httpClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tt.expectStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectCalls, atomic.LoadInt32(&calls))
+			assert.Len(t, slept, int(tt.expectCalls)-1)
+		})
+	}
+}
+
+func TestRetryTransportLeavesRequestAlone(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"test"}`, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	transport := newTestRetryClient(DefaultRetryPolicy(), &slept).Transport
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	body := req.Body
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.True(t, body == req.Body, "the caller's request body was replaced")
+}
+
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	resp, err := newTestRetryClient(DefaultRetryPolicy(), &slept).Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, slept)
+}
+
+func TestRetryAfter(t *testing.T) {
+	wait, ok := retryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	wait, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = retryAfter("")
+	assert.False(t, ok)
+	_, ok = retryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestBackoffStaysWithinBounds(t *testing.T) {
+	transport := &retryTransport{policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 4 * time.Second}}
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := transport.backoff(attempt)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, 4*time.Second)
+	}
+}
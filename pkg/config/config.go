@@ -36,8 +36,25 @@ type Config struct {
 	Environment      string             `mapstructure:"environment"`
 	Timeout          int                `mapstructure:"timeout"`
 	DisableAnalytics bool               `mapstructure:"disable_analytics"`
-	Accounts         map[string]Account `mapstructure:"accounts"`       // Multiple accounts support
-	ActiveAccount    string             `mapstructure:"active_account"` // Which account is currently active
+	MaxRetries       int                `mapstructure:"max_retries"`       // Retries for rate-limited or failed requests
+	RetryMaxDelay    int                `mapstructure:"retry_max_delay"`   // Longest wait between retries, in seconds
+	RetryAllMethods  bool               `mapstructure:"retry_all_methods"` // Also retry POST/PATCH on transient errors
+	Accounts         map[string]Account `mapstructure:"accounts"`          // Multiple accounts support
+	ActiveAccount    string             `mapstructure:"active_account"`    // Which account is currently active
+
+	stored  map[string]interface{} // runtimeKeys as written in the config file
+	persist map[string]bool        // runtimeKeys marked with Persist
+}
+
+// runtimeKeys are settings that --timeout, --max-retries and VAPI_*
+// variables can override for a single run. SaveConfig writes them from the
+// config file unless Persist marked a new value, so one-off overrides are
+// never saved.
+var runtimeKeys = map[string]interface{}{
+	"timeout":           30,
+	"max_retries":       3,
+	"retry_max_delay":   30,
+	"retry_all_methods": false,
 }
 
 // Account represents a single authenticated account/organization
@@ -91,9 +108,11 @@ func LoadConfig() (*Config, error) {
 	viper.AutomaticEnv()
 
 	// Set defaults
-	viper.SetDefault("timeout", 30)
 	viper.SetDefault("environment", "production")
 	viper.SetDefault("disable_analytics", false)
+	for key, value := range runtimeKeys {
+		viper.SetDefault(key, value)
+	}
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -108,6 +127,7 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	config.stored = storedSettings(viper.ConfigFileUsed())
 
 	// Apply environment-specific URLs if not explicitly set
 	if err := config.applyEnvironment(); err != nil {
@@ -132,22 +152,10 @@ func (c *Config) applyEnvironment() error {
 		env = "production"
 	}
 
-	// Normalize environment name
-	env = strings.ToLower(env)
-	if env == "dev" || env == "local" {
-		env = "development"
-	}
-	if env == "stage" {
-		env = "staging"
-	}
-	if env == "prod" {
-		env = "production"
-	}
-
 	// Get environment configuration
-	envConfig, exists := environments[env]
-	if !exists {
-		return fmt.Errorf("unknown environment: %s (valid: production, staging, development)", env)
+	envConfig, err := lookupEnvironment(env)
+	if err != nil {
+		return err
 	}
 
 	// Update environment field
@@ -165,6 +173,26 @@ func (c *Config) applyEnvironment() error {
 	return nil
 }
 
+// lookupEnvironment normalizes an environment name and returns its settings
+func lookupEnvironment(env string) (Environment, error) {
+	env = strings.ToLower(env)
+	if env == "dev" || env == "local" {
+		env = "development"
+	}
+	if env == "stage" {
+		env = "staging"
+	}
+	if env == "prod" {
+		env = "production"
+	}
+
+	envConfig, exists := environments[env]
+	if !exists {
+		return Environment{}, fmt.Errorf("unknown environment: %s (valid: production, staging, development)", env)
+	}
+	return envConfig, nil
+}
+
 // isDefaultURL checks if a URL is a default URL from any environment
 func (c *Config) isDefaultURL(url string) bool {
 	for _, env := range environments {
@@ -206,13 +234,64 @@ func (c *Config) GetEnvironment() string {
 	return c.Environment
 }
 
+// storedSettings reads the runtimeKeys present in the config file at path,
+// without the flags and environment variables viper layers on top
+func storedSettings(path string) map[string]interface{} {
+	stored := make(map[string]interface{})
+	if path == "" {
+		return stored
+	}
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		return stored
+	}
+	for key := range runtimeKeys {
+		if file.IsSet(key) {
+			stored[key] = file.Get(key)
+		}
+	}
+	return stored
+}
+
+// Persist marks a runtime setting such as timeout as changed, so SaveConfig
+// writes its current value instead of the one in the config file
+func (c *Config) Persist(key string) {
+	if c.persist == nil {
+		c.persist = make(map[string]bool)
+	}
+	c.persist[key] = true
+}
+
+// runtimeValue returns the value SaveConfig writes for one of runtimeKeys
+func (c *Config) runtimeValue(key string) interface{} {
+	if c.persist[key] {
+		switch key {
+		case "timeout":
+			return c.Timeout
+		case "max_retries":
+			return c.MaxRetries
+		case "retry_max_delay":
+			return c.RetryMaxDelay
+		case "retry_all_methods":
+			return c.RetryAllMethods
+		}
+	}
+	if value, ok := c.stored[key]; ok {
+		return value
+	}
+	return runtimeKeys[key]
+}
+
 func SaveConfig(config *Config) error {
 	viper.Set("api_key", config.APIKey)
 	viper.Set("base_url", config.BaseURL)
 	viper.Set("dashboard_url", config.DashboardURL)
 	viper.Set("environment", config.Environment)
-	viper.Set("timeout", config.Timeout)
 	viper.Set("disable_analytics", config.DisableAnalytics)
+	for key := range runtimeKeys {
+		viper.Set(key, config.runtimeValue(key))
+	}
 	viper.Set("accounts", config.Accounts)
 	viper.Set("active_account", config.ActiveAccount)
 
@@ -290,6 +369,29 @@ func (c *Config) SetActiveAccount(accountKey string) error {
 	return nil
 }
 
+// UseAccount points this config at another configured account, including
+// the account's own environment, without saving it as the active account
+func (c *Config) UseAccount(accountKey string) error {
+	if err := c.SetActiveAccount(accountKey); err != nil {
+		return err
+	}
+	account := c.Accounts[accountKey]
+	if account.APIKey == "" {
+		return fmt.Errorf("account '%s' has no API key; run 'vapi login' again", accountKey)
+	}
+
+	if account.Environment != "" {
+		envConfig, err := lookupEnvironment(account.Environment)
+		if err != nil {
+			return err
+		}
+		c.Environment = envConfig.Name
+		c.BaseURL = envConfig.APIBaseURL
+		c.DashboardURL = envConfig.DashboardURL
+	}
+	return nil
+}
+
 // ListAccounts returns all configured accounts
 func (c *Config) ListAccounts() map[string]Account {
 	if c.Accounts == nil {
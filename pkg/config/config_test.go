@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveConfigSkipsFlagOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(home, ".vapi-cli.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("timeout: 10\n"), 0o600))
+
+	// vapi --timeout 5 --max-retries 0 config set max_retries 1
+	flags := pflag.NewFlagSet("vapi", pflag.ContinueOnError)
+	flags.Int("timeout", 30, "")
+	flags.Int("max-retries", 3, "")
+	require.NoError(t, viper.BindPFlag("timeout", flags.Lookup("timeout")))
+	require.NoError(t, viper.BindPFlag("max_retries", flags.Lookup("max-retries")))
+	require.NoError(t, flags.Parse([]string{"--timeout", "5", "--max-retries", "0"}))
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.Timeout)
+
+	cfg.MaxRetries = 1
+	cfg.Persist("max_retries")
+	require.NoError(t, SaveConfig(cfg))
+
+	saved := viper.New()
+	saved.SetConfigFile(path)
+	require.NoError(t, saved.ReadInConfig())
+	assert.Equal(t, 10, saved.GetInt("timeout"))
+	assert.Equal(t, 1, saved.GetInt("max_retries"))
+	assert.Equal(t, 30, saved.GetInt("retry_max_delay"))
+}
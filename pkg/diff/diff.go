@@ -0,0 +1,213 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// ContextLines is how many unchanged lines surround each hunk
+const ContextLines = 3
+
+// Op says whether a line is shared, only on the left or only on the right
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Hunk is a run of changes with its surrounding context. Starts are 1-based
+// line numbers as in a unified diff header.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []Line
+}
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true)
+	hunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF"))
+	deleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6347"))
+	insertStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#62F6B5"))
+)
+
+// Document renders v as YAML with sorted keys. Multi-line strings such as
+// prompts become block scalars, so they are compared line by line rather
+// than as one long value.
+func Document(v interface{}) ([]string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render document: %w", err)
+	}
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+// Lines returns the shortest edit script turning a into b, built from the
+// longest common subsequence of lines
+func Lines(a, b []string) []Line {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Delete, a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Insert, b[j]})
+	}
+	return lines
+}
+
+// Hunks groups the changes in lines into hunks with up to context
+// unchanged lines on either side. Hunks whose context would overlap are
+// merged.
+func Hunks(lines []Line, context int) []Hunk {
+	// Line numbers on each side before every entry of lines
+	oldAt := make([]int, len(lines)+1)
+	newAt := make([]int, len(lines)+1)
+	oldAt[0], newAt[0] = 1, 1
+	for i, line := range lines {
+		oldAt[i+1], newAt[i+1] = oldAt[i], newAt[i]
+		if line.Op != Insert {
+			oldAt[i+1]++
+		}
+		if line.Op != Delete {
+			newAt[i+1]++
+		}
+	}
+
+	var hunks []Hunk
+	for i := 0; i < len(lines); i++ {
+		if lines[i].Op == Equal {
+			continue
+		}
+
+		// Extend while the next change is close enough to share context
+		last := i
+		for j := i + 1; j < len(lines) && j-last <= 2*context+1; j++ {
+			if lines[j].Op != Equal {
+				last = j
+			}
+		}
+
+		start := max(i-context, 0)
+		end := min(last+context+1, len(lines))
+		hunks = append(hunks, Hunk{
+			OldStart: oldAt[start],
+			OldLines: oldAt[end] - oldAt[start],
+			NewStart: newAt[start],
+			NewLines: newAt[end] - newAt[start],
+			Lines:    lines[start:end],
+		})
+		i = last
+	}
+	return hunks
+}
+
+// Unified writes a colored unified diff of a and b and reports whether they
+// differ. Nothing is written when they are equal.
+func Unified(w io.Writer, oldName, newName string, a, b []string) bool {
+	hunks := Hunks(Lines(a, b), ContextLines)
+	if len(hunks) == 0 {
+		return false
+	}
+
+	fmt.Fprintln(w, paint(headerStyle, "--- "+oldName))
+	fmt.Fprintln(w, paint(headerStyle, "+++ "+newName))
+	for _, h := range hunks {
+		fmt.Fprintln(w, paint(hunkStyle, fmt.Sprintf("@@ -%s +%s @@", span(h.OldStart, h.OldLines), span(h.NewStart, h.NewLines))))
+		for _, line := range h.Lines {
+			switch line.Op {
+			case Delete:
+				fmt.Fprintln(w, paint(deleteStyle, "-"+line.Text))
+			case Insert:
+				fmt.Fprintln(w, paint(insertStyle, "+"+line.Text))
+			default:
+				fmt.Fprintln(w, " "+line.Text)
+			}
+		}
+	}
+	return true
+}
+
+// paint wraps s in style's escape codes, or returns it unchanged when the
+// terminal has no color. lipgloss's Render expands tabs and pads lines, which
+// would change the whitespace being compared, so only the codes it puts
+// around a placeholder are used.
+func paint(style lipgloss.Style, s string) string {
+	open, end, _ := strings.Cut(style.Render("x"), "x")
+	return open + s + end
+}
+
+// span formats a hunk range; an empty range points at the line before it
+func span(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if n == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentSplitsPrompts(t *testing.T) {
+	lines, err := Document(map[string]interface{}{
+		"name":  "support",
+		"model": map[string]interface{}{"messages": []interface{}{map[string]interface{}{"content": "You are helpful.\nBe brief.\n"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"model:",
+		"  messages:",
+		"    - content: |",
+		"        You are helpful.",
+		"        Be brief.",
+		"name: support",
+	}, lines)
+}
+
+func TestUnified(t *testing.T) {
+	a := strings.Split("a b c d e f g h i j k l m", " ")
+	b := strings.Split("a b c D e f g h i j k l m n", " ")
+
+	var out bytes.Buffer
+	assert.True(t, Unified(&out, "old", "new", a, b))
+	assert.Equal(t, `--- old
++++ new
+@@ -1,7 +1,7 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+@@ -11,3 +11,4 @@
+ k
+ l
+ m
++n
+`, out.String())
+
+	out.Reset()
+	assert.False(t, Unified(&out, "old", "new", a, a))
+	assert.Empty(t, out.String())
+}
+
+func TestUnifiedKeepsWhitespace(t *testing.T) {
+	var out bytes.Buffer
+	assert.True(t, Unified(&out, "old", "new", []string{"\tindented"}, []string{"\tindented  "}))
+	assert.Contains(t, out.String(), "-\tindented\n+\tindented  \n")
+}
+
+func TestHunksMergeNearbyChanges(t *testing.T) {
+	a := strings.Split("a b c d e f", " ")
+	b := strings.Split("a X c d Y f", " ")
+
+	hunks := Hunks(Lines(a, b), 1)
+	require.Len(t, hunks, 1)
+	assert.Equal(t, Hunk{OldStart: 1, OldLines: 6, NewStart: 1, NewLines: 6, Lines: hunks[0].Lines}, hunks[0])
+}
@@ -0,0 +1,199 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// Kinds are the resource types included in the graph
+var Kinds = []client.ResourceKind{
+	client.Assistants,
+	client.Tools,
+	client.PhoneNumbers,
+	client.Workflows,
+	client.Squads,
+	client.Campaigns,
+}
+
+// Remote is the API surface the graph needs, satisfied by *client.VapiClient
+type Remote interface {
+	ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error)
+}
+
+// Node is one resource in the org
+type Node struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// Label names the node for people, e.g. `assistant "support" (a1)`
+func (n Node) Label() string {
+	if n.Name == "" {
+		return fmt.Sprintf("%s %s", n.Kind, n.ID)
+	}
+	return fmt.Sprintf("%s %q (%s)", n.Kind, n.Name, n.ID)
+}
+
+// Edge says that From references To through the field at Field
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Field string `json:"field"`
+}
+
+// Graph holds every resource in the org and the references between them
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	index map[string]int
+}
+
+// Build lists every resource of Kinds and links each one to the resources
+// whose IDs appear anywhere in its configuration, such as toolIds, a phone
+// number's assistantId, squad members or workflow nodes
+func Build(ctx context.Context, remote Remote) (*Graph, error) {
+	g := &Graph{index: make(map[string]int)}
+	var items []map[string]interface{}
+	for _, kind := range Kinds {
+		list, err := remote.ListAllRaw(ctx, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", kind.Name, err)
+		}
+		for _, item := range list {
+			id, _ := item["id"].(string)
+			if id == "" {
+				continue
+			}
+			g.index[id] = len(g.Nodes)
+			g.Nodes = append(g.Nodes, Node{Kind: kind.Name, ID: id, Name: kind.NameOf(item)})
+			items = append(items, item)
+		}
+	}
+
+	for i, item := range items {
+		from := g.Nodes[i].ID
+		seen := make(map[string]bool)
+		for key, value := range item {
+			if key == "id" || key == "orgId" {
+				continue
+			}
+			findIDs(value, key, func(path, id string) {
+				if _, ok := g.index[id]; !ok || id == from || seen[path+"|"+id] {
+					return
+				}
+				seen[path+"|"+id] = true
+				g.Edges = append(g.Edges, Edge{From: from, To: id, Field: path})
+			})
+		}
+	}
+
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return g.index[a.From] < g.index[b.From]
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		return g.index[a.To] < g.index[b.To]
+	})
+	return g, nil
+}
+
+// Node returns the resource with id
+func (g *Graph) Node(id string) (Node, bool) {
+	i, ok := g.index[id]
+	if !ok {
+		return Node{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// Dependents returns the references pointing at id
+func (g *Graph) Dependents(id string) []Edge {
+	var edges []Edge
+	for _, e := range g.Edges {
+		if e.To == id {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// WriteText lists each resource that references others, followed by what
+// it references
+func (g *Graph) WriteText(w io.Writer) {
+	from := ""
+	for _, e := range g.Edges {
+		if e.From != from {
+			from = e.From
+			node, _ := g.Node(from)
+			fmt.Fprintln(w, node.Label())
+		}
+		to, _ := g.Node(e.To)
+		fmt.Fprintf(w, "  → %s  via %s\n", to.Label(), e.Field)
+	}
+}
+
+// WriteDOT renders the graph in Graphviz DOT format
+func (g *Graph) WriteDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph vapi {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, n := range g.Nodes {
+		label := n.Kind + "\\n" + n.ID
+		if n.Name != "" {
+			label = n.Kind + "\\n" + n.Name
+		}
+		fmt.Fprintf(w, "  %s [label=%s];\n", dotQuote(n.ID), dotQuote(label))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(w, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(e.Field))
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// findIDs calls fn with the dotted path of every string inside v
+func findIDs(v interface{}, path string, fn func(path, value string)) {
+	switch value := v.(type) {
+	case string:
+		fn(path, value)
+	case map[string]interface{}:
+		for k, item := range value {
+			findIDs(item, path+"."+k, fn)
+		}
+	case []interface{}:
+		for i, item := range value {
+			findIDs(item, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+type fakeRemote map[string][]map[string]interface{}
+
+func (f fakeRemote) ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error) {
+	return f[kind.Path], nil
+}
+
+func TestBuild(t *testing.T) {
+	remote := fakeRemote{
+		"/assistant": {
+			{"id": "a1", "name": "support", "orgId": "o1", "model": map[string]interface{}{"toolIds": []interface{}{"t1", "unknown"}}},
+			{"id": "a2", "name": "sales"},
+		},
+		"/tool": {
+			{"id": "t1", "function": map[string]interface{}{"name": "lookup"}},
+			{"id": "t2", "function": map[string]interface{}{"name": "transfer"}, "destinations": []interface{}{map[string]interface{}{"assistantId": "a2"}}},
+		},
+		"/phone-number": {
+			{"id": "p1", "number": "+15550100", "assistantId": "a1"},
+		},
+		"/squad": {
+			{"id": "s1", "name": "team", "members": []interface{}{
+				map[string]interface{}{"assistantId": "a1"},
+				map[string]interface{}{"assistantId": "a2"},
+			}},
+		},
+	}
+
+	g, err := Build(context.Background(), remote)
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 6)
+	assert.Equal(t, []Edge{
+		{From: "a1", To: "t1", Field: "model.toolIds[0]"},
+		{From: "t2", To: "a2", Field: "destinations[0].assistantId"},
+		{From: "p1", To: "a1", Field: "assistantId"},
+		{From: "s1", To: "a1", Field: "members[0].assistantId"},
+		{From: "s1", To: "a2", Field: "members[1].assistantId"},
+	}, g.Edges)
+
+	// Map iteration order doesn't leak into the output
+	for i := 0; i < 20; i++ {
+		again, err := Build(context.Background(), remote)
+		require.NoError(t, err)
+		require.Equal(t, g.Edges, again.Edges)
+	}
+
+	assert.Equal(t, []Edge{
+		{From: "p1", To: "a1", Field: "assistantId"},
+		{From: "s1", To: "a1", Field: "members[0].assistantId"},
+	}, g.Dependents("a1"))
+	assert.Empty(t, g.Dependents("o1"))
+
+	var text bytes.Buffer
+	g.WriteText(&text)
+	assert.Contains(t, text.String(), "assistant \"support\" (a1)\n  → tool \"lookup\" (t1)  via model.toolIds[0]\n")
+
+	var dot bytes.Buffer
+	g.WriteDOT(&dot)
+	assert.Contains(t, dot.String(), `"p1" -> "a1" [label="assistantId"];`)
+	assert.Contains(t, dot.String(), `"t1" [label="tool\nlookup"];`)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// Severity ranks how serious a finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Finding is one problem reported by a rule
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path,omitempty"` // Dotted path of the offending field
+	Message  string   `json:"message"`
+}
+
+// Rule is a single check run against an assistant
+type Rule struct {
+	ID          string
+	Description string
+	Check       func(ctx context.Context, t *Target) ([]Finding, error)
+}
+
+var registry []Rule
+
+// Register adds a rule to the set run by Run. Rule IDs must be unique.
+func Register(rule Rule) {
+	for _, r := range registry {
+		if r.ID == rule.ID {
+			panic(fmt.Sprintf("lint rule %q registered twice", rule.ID))
+		}
+	}
+	registry = append(registry, rule)
+}
+
+// Rules returns every registered rule in registration order
+func Rules() []Rule {
+	return append([]Rule(nil), registry...)
+}
+
+// Remote is the API surface reference checks need, satisfied by
+// *client.VapiClient
+type Remote interface {
+	ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error)
+}
+
+// Target is the assistant being linted and what rules may know about its
+// surroundings
+type Target struct {
+	Assistant  map[string]interface{}
+	Production bool            // The account is on the production environment
+	Variables  map[string]bool // Template variables supplied at call time
+
+	// Remote is used to check that referenced resources exist. Reference
+	// checks are skipped when it is nil.
+	Remote Remote
+
+	ids map[string]map[string]bool
+}
+
+// Exists reports whether the account has a resource of kind with id. Each
+// kind is listed at most once per target.
+func (t *Target) Exists(ctx context.Context, kind client.ResourceKind, id string) (bool, error) {
+	if t.ids == nil {
+		t.ids = make(map[string]map[string]bool)
+	}
+	ids, ok := t.ids[kind.Path]
+	if !ok {
+		items, err := t.Remote.ListAllRaw(ctx, kind)
+		if err != nil {
+			return false, fmt.Errorf("failed to list %ss: %w", kind.Name, err)
+		}
+		ids = make(map[string]bool, len(items))
+		for _, item := range items {
+			if id, ok := item["id"].(string); ok {
+				ids[id] = true
+			}
+		}
+		t.ids[kind.Path] = ids
+	}
+	return ids[id], nil
+}
+
+// Run checks t against rules, skipping the rule IDs in disabled. Findings
+// are ordered by severity, then path.
+func Run(ctx context.Context, t *Target, rules []Rule, disabled []string) ([]Finding, error) {
+	skip := make(map[string]bool, len(disabled))
+	for _, id := range disabled {
+		skip[id] = true
+	}
+
+	findings := []Finding{}
+	for _, rule := range rules {
+		if skip[rule.ID] {
+			continue
+		}
+		found, err := rule.Check(ctx, t)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+		for _, f := range found {
+			f.Rule = rule.ID
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if a, b := findings[i].Severity.rank(), findings[j].Severity.rank(); a != b {
+			return a < b
+		}
+		return findings[i].Path < findings[j].Path
+	})
+	return findings, nil
+}
+
+// Count returns how many findings have severity
+func Count(findings []Finding, severity Severity) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,96 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+type fakeRemote struct {
+	items map[string][]map[string]interface{}
+	calls int
+}
+
+func (f *fakeRemote) ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error) {
+	f.calls++
+	return f.items[kind.Path], nil
+}
+
+func TestRun(t *testing.T) {
+	remote := &fakeRemote{items: map[string][]map[string]interface{}{
+		"/tool": {{"id": "t1"}},
+	}}
+	target := &Target{
+		Assistant: map[string]interface{}{
+			"firstMessageMode": "assistant-speaks-first",
+			"firstMessage":     "",
+			"server":           map[string]interface{}{"url": "http://example.com/hook"},
+			"model": map[string]interface{}{
+				"toolIds": []interface{}{"t1", "t-gone", "t-gone-2"},
+				"messages": []interface{}{
+					map[string]interface{}{"role": "system", "content": "Greet {{ customer.name }} about {{order_id}} by {{date}}. Ask about {{ topic | default: 'billing' }} and {{supplied}}."},
+				},
+			},
+		},
+		Production: true,
+		Variables:  map[string]bool{"supplied": true},
+		Remote:     remote,
+	}
+
+	findings, err := Run(context.Background(), target, Rules(), nil)
+	require.NoError(t, err)
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, string(f.Severity)+" "+f.Rule+" "+f.Path)
+	}
+	assert.Equal(t, []string{
+		"error first-message firstMessage",
+		"error missing-tool model.toolIds[1]",
+		"error missing-tool model.toolIds[2]",
+		"error insecure-server-url server.url",
+		"warning undefined-variable model.messages[0].content",
+	}, got)
+	assert.Contains(t, findings[4].Message, "{{order_id}}")
+	assert.Equal(t, 1, remote.calls, "each kind is listed once")
+	assert.Equal(t, 4, Count(findings, SeverityError))
+
+	findings, err = Run(context.Background(), target, Rules(), []string{"missing-tool", "first-message", "insecure-server-url", "undefined-variable"})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestDefaultsAndOffline(t *testing.T) {
+	target := &Target{Assistant: map[string]interface{}{
+		"firstMessageMode": "assistant-waits-for-user",
+		"server":           map[string]interface{}{"url": "http://localhost:3000"},
+		"credentialIds":    []interface{}{"c1"},
+	}}
+
+	findings, err := Run(context.Background(), target, Rules(), nil)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, Finding{Rule: "system-prompt", Severity: SeverityWarning, Path: "model", Message: "no model is configured"}, findings[0])
+	assert.Equal(t, "insecure-server-url", findings[1].Rule)
+	assert.Equal(t, SeverityWarning, findings[1].Severity, "plain http is only an error in production")
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, "assistant.yaml", Rules(), []Finding{
+		{Rule: "first-message", Severity: SeverityError, Path: "firstMessage", Message: "empty"},
+	}))
+
+	var log map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log["version"])
+	result := log["runs"].([]interface{})[0].(map[string]interface{})["results"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "first-message", result["ruleId"])
+	assert.Equal(t, "error", result["level"])
+}
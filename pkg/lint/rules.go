@@ -0,0 +1,266 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// builtinVariables are template variables Vapi fills in on every call
+var builtinVariables = map[string]bool{
+	"now": true, "date": true, "time": true, "year": true, "month": true, "day": true,
+	"customer": true, "phoneNumber": true, "call": true, "transport": true,
+}
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([^}]*?)\s*\}\}`)
+
+func init() {
+	Register(Rule{
+		ID:          "first-message",
+		Description: "An assistant that speaks first needs a firstMessage",
+		Check:       checkFirstMessage,
+	})
+	Register(Rule{
+		ID:          "system-prompt",
+		Description: "The model should have a system prompt",
+		Check:       checkSystemPrompt,
+	})
+	Register(Rule{
+		ID:          "insecure-server-url",
+		Description: "Server URLs must use https in production",
+		Check:       checkServerURLs,
+	})
+	Register(Rule{
+		ID:          "undefined-variable",
+		Description: "Template {{variables}} must be built in or supplied at call time",
+		Check:       checkVariables,
+	})
+	Register(Rule{
+		ID:          "missing-tool",
+		Description: "model.toolIds must reference existing tools",
+		Check:       referenceCheck(client.Tools, "toolIds"),
+	})
+	Register(Rule{
+		ID:          "missing-credential",
+		Description: "Credential IDs must reference existing credentials",
+		Check:       referenceCheck(client.Credentials, "credentialIds", "credentialId"),
+	})
+	Register(Rule{
+		ID:          "missing-phone-number",
+		Description: "Phone number IDs must reference existing phone numbers",
+		Check:       referenceCheck(client.PhoneNumbers, "phoneNumberId"),
+	})
+}
+
+func checkFirstMessage(ctx context.Context, t *Target) ([]Finding, error) {
+	mode, _ := t.Assistant["firstMessageMode"].(string)
+	message, _ := t.Assistant["firstMessage"].(string)
+	if strings.TrimSpace(message) != "" {
+		return nil, nil
+	}
+	switch mode {
+	case "assistant-speaks-first":
+		return []Finding{{
+			Severity: SeverityError,
+			Path:     "firstMessage",
+			Message:  "firstMessageMode is assistant-speaks-first but firstMessage is empty",
+		}}, nil
+	case "":
+		return []Finding{{
+			Severity: SeverityWarning,
+			Path:     "firstMessage",
+			Message:  "firstMessage is empty; the assistant speaks first by default and will stay silent",
+		}}, nil
+	}
+	return nil, nil
+}
+
+func checkSystemPrompt(ctx context.Context, t *Target) ([]Finding, error) {
+	model, ok := t.Assistant["model"].(map[string]interface{})
+	if !ok {
+		return []Finding{{Severity: SeverityWarning, Path: "model", Message: "no model is configured"}}, nil
+	}
+	messages, _ := model["messages"].([]interface{})
+	for _, m := range messages {
+		if msg, ok := m.(map[string]interface{}); ok && msg["role"] == "system" {
+			if content, _ := msg["content"].(string); strings.TrimSpace(content) != "" {
+				return nil, nil
+			}
+		}
+	}
+	return []Finding{{Severity: SeverityWarning, Path: "model.messages", Message: "the model has no system prompt"}}, nil
+}
+
+func checkServerURLs(ctx context.Context, t *Target) ([]Finding, error) {
+	severity := SeverityWarning
+	if t.Production {
+		severity = SeverityError
+	}
+
+	var findings []Finding
+	walk(t.Assistant, "", func(path, key string, value interface{}) {
+		url, ok := value.(string)
+		if !ok || !strings.HasPrefix(strings.ToLower(url), "http://") {
+			return
+		}
+		if key == "serverUrl" || strings.HasSuffix(path, "server.url") {
+			findings = append(findings, Finding{
+				Severity: severity,
+				Path:     path,
+				Message:  fmt.Sprintf("%s uses plain http; webhooks carry call data and secrets", url),
+			})
+		}
+	})
+	return findings, nil
+}
+
+func checkVariables(ctx context.Context, t *Target) ([]Finding, error) {
+	supplied := make(map[string]bool)
+	for name := range t.Variables {
+		supplied[name] = true
+	}
+	if values, ok := t.Assistant["variableValues"].(map[string]interface{}); ok {
+		for name := range values {
+			supplied[name] = true
+		}
+	}
+
+	// Report each variable once, at its first use
+	firstUse := make(map[string]string)
+	walk(t.Assistant, "", func(path, key string, value interface{}) {
+		text, ok := value.(string)
+		if !ok {
+			return
+		}
+		for _, match := range variablePattern.FindAllStringSubmatch(text, -1) {
+			name, hasDefault := variableName(match[1])
+			if name == "" || hasDefault || builtinVariables[name] || supplied[name] {
+				continue
+			}
+			if _, seen := firstUse[name]; !seen {
+				firstUse[name] = path
+			}
+		}
+	})
+
+	names := make([]string, 0, len(firstUse))
+	for name := range firstUse {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	findings := make([]Finding, 0, len(names))
+	for _, name := range names {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Path:     firstUse[name],
+			Message:  fmt.Sprintf("{{%s}} is not a built-in variable and is never supplied; pass it in variableValues or with --var", name),
+		})
+	}
+	return findings, nil
+}
+
+// variableName returns the root variable of a Liquid expression such as
+// "customer.name | default: 'there'", and whether it has a default
+func variableName(expr string) (string, bool) {
+	head, filters, _ := strings.Cut(expr, "|")
+	head = strings.TrimSpace(head)
+	if head == "" || strings.ContainsAny(head[:1], `"'0123456789`) {
+		return "", false
+	}
+	root, _, _ := strings.Cut(head, ".")
+	root, _, _ = strings.Cut(root, "[")
+	return root, strings.Contains(filters, "default")
+}
+
+// referenceCheck reports IDs under any of keys that don't exist in the
+// account. Keys may hold a single ID or a list of IDs.
+func referenceCheck(kind client.ResourceKind, keys ...string) func(context.Context, *Target) ([]Finding, error) {
+	return func(ctx context.Context, t *Target) ([]Finding, error) {
+		if t.Remote == nil {
+			return nil, nil
+		}
+
+		type ref struct{ path, id string }
+		var refs []ref
+		walk(t.Assistant, "", func(path, key string, value interface{}) {
+			for _, k := range keys {
+				if key != k {
+					continue
+				}
+				switch v := value.(type) {
+				case string:
+					refs = append(refs, ref{path, v})
+				case []interface{}:
+					for i, item := range v {
+						if id, ok := item.(string); ok {
+							refs = append(refs, ref{fmt.Sprintf("%s[%d]", path, i), id})
+						}
+					}
+				}
+			}
+		})
+
+		var findings []Finding
+		for _, r := range refs {
+			ok, err := t.Exists(ctx, kind, r.id)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				findings = append(findings, Finding{
+					Severity: SeverityError,
+					Path:     r.path,
+					Message:  fmt.Sprintf("%s %s does not exist in this account", kind.Name, r.id),
+				})
+			}
+		}
+		return findings, nil
+	}
+}
+
+// walk calls fn for every value in v with its dotted path and the key it is
+// stored under. List elements get an [i] suffix and an empty key.
+func walk(v interface{}, path string, fn func(path, key string, value interface{})) {
+	var visit func(v interface{}, path, key string)
+	visit = func(v interface{}, path, key string) {
+		fn(path, key, v)
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for k, item := range value {
+				child := k
+				if path != "" {
+					child = path + "." + k
+				}
+				visit(item, child, k)
+			}
+		case []interface{}:
+			for i, item := range value {
+				visit(item, fmt.Sprintf("%s[%d]", path, i), "")
+			}
+		}
+	}
+	visit(v, path, "")
+}
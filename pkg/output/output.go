@@ -19,10 +19,17 @@ Authors:
 package output
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"strings"
 	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
 )
 
 type OutputFormat string
@@ -31,51 +38,269 @@ const (
 	FormatJSON  OutputFormat = "json"
 	FormatTable OutputFormat = "table"
 	FormatYAML  OutputFormat = "yaml"
+	FormatCSV   OutputFormat = "csv"
 )
 
+// Formats lists every supported output format in display order
+var Formats = []OutputFormat{FormatTable, FormatJSON, FormatYAML, FormatCSV}
+
+// currentFormat is the format selected with --output. Empty means each
+// command falls back to its own default (table for lists, JSON for details).
+var currentFormat OutputFormat
+
+// ParseFormat validates a user-supplied format name
+func ParseFormat(s string) (OutputFormat, error) {
+	if s == "" {
+		return "", nil
+	}
+	format := OutputFormat(strings.ToLower(strings.TrimSpace(s)))
+	for _, f := range Formats {
+		if f == format {
+			return format, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown output format %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// SetFormat sets the global output format
+func SetFormat(format OutputFormat) {
+	currentFormat = format
+}
+
+// GetFormat returns the global output format, or empty if none was selected
+func GetFormat() OutputFormat {
+	return currentFormat
+}
+
+// IsStructured reports whether the selected format is meant for machines
+// rather than people, so commands can skip human-only hints
+func IsStructured() bool {
+	return currentFormat == FormatJSON || currentFormat == FormatYAML || currentFormat == FormatCSV
+}
+
+// Table is the tabular view of a resource used by the table and CSV formats
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Render writes data to stdout in the globally selected format, after
+// applying any --query selector or --template.
+//
+// The table view is used for table and CSV output. When it is nil (as for
+// single-resource get commands) a FIELD/VALUE view of data is built instead
+// and JSON becomes the default format.
+func Render(data interface{}, table *Table) error {
+	// --query narrows the data before any formatting happens
+	if currentQuery != "" {
+		result, err := Query(data, currentQuery)
+		if err != nil {
+			return err
+		}
+		if currentTemplate != "" {
+			return ExecuteTemplate(os.Stdout, currentTemplate, result)
+		}
+		return writeQueryResult(os.Stdout, currentFormat, result)
+	}
+
+	// --template takes over formatting entirely
+	if currentTemplate != "" {
+		return ExecuteTemplate(os.Stdout, currentTemplate, data)
+	}
+
+	format := currentFormat
+	if format == "" {
+		format = FormatJSON
+		if table != nil {
+			format = FormatTable
+		}
+	}
+	return Write(os.Stdout, format, data, table)
+}
+
+// Write encodes data to w in the given format
+func Write(w io.Writer, format OutputFormat, data interface{}, table *Table) error {
+	// Encode empty lists as [] rather than null
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		data = []interface{}{}
+	}
+
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, data)
+	case FormatYAML:
+		return writeYAML(w, data)
+	case FormatTable, FormatCSV:
+		if table == nil {
+			var err error
+			table, err = fieldTable(data)
+			if err != nil {
+				return err
+			}
+		}
+		if format == FormatCSV {
+			return writeCSV(w, table.Headers, table.Rows)
+		}
+		return writeTable(w, table.Headers, table.Rows)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
 func PrintJSON(data interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
+	return writeJSON(os.Stdout, data)
+}
+
+// PrintYAML writes data to stdout as YAML
+func PrintYAML(data interface{}) error {
+	return writeYAML(os.Stdout, data)
+}
+
+// PrintCSV writes headers and rows to stdout as CSV
+func PrintCSV(headers []string, rows [][]string) error {
+	return writeCSV(os.Stdout, headers, rows)
+}
+
+func PrintTable(headers []string, rows [][]string) {
+	if err := writeTable(os.Stdout, headers, rows); err != nil {
+		fmt.Printf("Warning: failed to flush table writer: %v\n", err)
+	}
+}
+
+// ToGeneric converts data into plain maps, slices and scalars by
+// round-tripping it through JSON. SDK types only carry JSON tags, so this
+// keeps field names consistent across every encoder.
+func ToGeneric(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+	return generic, nil
+}
+
+func writeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(data)
 }
 
-func PrintTable(headers []string, rows [][]string) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+func writeYAML(w io.Writer, data interface{}) error {
+	generic, err := ToGeneric(data)
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlValue(generic)); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return encoder.Close()
+}
 
-	// Print headers
-	for i, h := range headers {
-		if i > 0 {
-			if _, err := fmt.Fprint(w, "\t"); err != nil {
-				return
-			}
+// yamlValue converts json.Number leaves into real numbers so YAML does not
+// quote them as strings
+func yamlValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = yamlValue(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = yamlValue(item)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
 		}
-		if _, err := fmt.Fprint(w, h); err != nil {
-			return
+		if f, err := val.Float64(); err == nil {
+			return f
 		}
+		return val.String()
+	default:
+		return val
+	}
+}
+
+func writeCSV(w io.Writer, headers []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
 	}
-	if _, err := fmt.Fprintln(w); err != nil {
-		return
+	return nil
+}
+
+func writeTable(w io.Writer, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	// Print headers
+	if _, err := fmt.Fprintln(tw, strings.Join(headers, "\t")); err != nil {
+		return err
 	}
 
 	// Print rows
 	for _, row := range rows {
-		for i, cell := range row {
-			if i > 0 {
-				if _, err := fmt.Fprint(w, "\t"); err != nil {
-					return
-				}
-			}
-			if _, err := fmt.Fprint(w, cell); err != nil {
-				return
-			}
-		}
-		if _, err := fmt.Fprintln(w); err != nil {
-			return
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
 		}
 	}
 
 	// Flush buffer
-	if err := w.Flush(); err != nil {
-		fmt.Printf("Warning: failed to flush table writer: %v\n", err)
+	return tw.Flush()
+}
+
+// fieldTable builds a FIELD/VALUE table from the top-level fields of data.
+// Nested objects are shown as compact JSON.
+func fieldTable(data interface{}) (*Table, error) {
+	generic, err := ToGeneric(data)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{Headers: []string{"FIELD", "VALUE"}}
+	obj, ok := generic.(map[string]interface{})
+	if !ok {
+		table.Rows = append(table.Rows, []string{"value", FormatValue(generic)})
+		return table, nil
+	}
+
+	for _, k := range sortedKeys(obj) {
+		table.Rows = append(table.Rows, []string{k, FormatValue(obj[k])})
+	}
+	return table, nil
+}
+
+// FormatValue renders a generic value as a single table cell
+func FormatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return fmt.Sprintf("%t", val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(b)
 	}
 }
@@ -0,0 +1,107 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected OutputFormat
+		hasError bool
+	}{
+		{"", "", false},
+		{"json", FormatJSON, false},
+		{"YAML", FormatYAML, false},
+		{" csv ", FormatCSV, false},
+		{"table", FormatTable, false},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, err := ParseFormat(tt.input)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	items := []sample{{ID: "a1", Name: "Alpha", Score: 1.5}, {ID: "b2", Name: "Beta, Inc", Score: 2}}
+	table := &Table{
+		Headers: []string{"ID", "NAME"},
+		Rows:    [][]string{{"a1", "Alpha"}, {"b2", "Beta, Inc"}},
+	}
+
+	tests := []struct {
+		name     string
+		format   OutputFormat
+		data     interface{}
+		table    *Table
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   FormatJSON,
+			data:     items[:1],
+			table:    table,
+			expected: "[\n  {\n    \"id\": \"a1\",\n    \"name\": \"Alpha\",\n    \"score\": 1.5\n  }\n]\n",
+		},
+		{
+			name:     "yaml uses json field names",
+			format:   FormatYAML,
+			data:     items[1],
+			expected: "id: b2\nname: Beta, Inc\nscore: 2\n",
+		},
+		{
+			name:     "csv quotes fields",
+			format:   FormatCSV,
+			data:     items,
+			table:    table,
+			expected: "ID,NAME\na1,Alpha\nb2,\"Beta, Inc\"\n",
+		},
+		{
+			name:     "table",
+			format:   FormatTable,
+			data:     items,
+			table:    table,
+			expected: "ID  NAME\na1  Alpha\nb2  Beta, Inc\n",
+		},
+		{
+			name:     "table falls back to field view",
+			format:   FormatTable,
+			data:     items[0],
+			expected: "FIELD  VALUE\nid     a1\nname   Alpha\nscore  1.5\n",
+		},
+		{
+			name:     "nil list encodes as empty array",
+			format:   FormatJSON,
+			data:     []sample(nil),
+			table:    &Table{Headers: []string{"ID"}},
+			expected: "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			require.NoError(t, Write(buf, tt.format, tt.data, tt.table))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
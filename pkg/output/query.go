@@ -0,0 +1,303 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Query and template selected with --query and --template
+var (
+	currentQuery    string
+	currentTemplate string
+)
+
+// SetQuery sets the global JSONPath-style field selector
+func SetQuery(query string) {
+	currentQuery = query
+}
+
+// SetTemplate sets the global Go text/template used to render output
+func SetTemplate(tmpl string) {
+	currentTemplate = tmpl
+}
+
+// HasSelector reports whether --query or --template was given, so commands
+// with a custom human view know to go through Render instead
+func HasSelector() bool {
+	return currentQuery != "" || currentTemplate != ""
+}
+
+// queryStep is one segment of a parsed query path
+type queryStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// Query evaluates a JSONPath-style expression against data.
+//
+// Supported syntax:
+//
+//	.field.nested       object fields (JSON names)
+//	.list[0] .list[-1]  array indices, negative counts from the end
+//	.list[*].id .*      wildcards over arrays and object values
+//	.["odd-key"]        quoted keys
+//
+// A leading "$" is optional. Missing fields evaluate to null.
+func Query(data interface{}, query string) (interface{}, error) {
+	steps, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	generic, err := ToGeneric(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track whether a wildcard fanned the result out into a list
+	values := []interface{}{generic}
+	multi := false
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range values {
+			selected, err := applyStep(v, step)
+			if err != nil {
+				return nil, fmt.Errorf("query %s: %w", query, err)
+			}
+			next = append(next, selected...)
+		}
+		if step.wildcard {
+			multi = true
+		}
+		values = next
+	}
+
+	if multi {
+		if values == nil {
+			values = []interface{}{}
+		}
+		return values, nil
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+func applyStep(v interface{}, step queryStep) ([]interface{}, error) {
+	switch {
+	case step.wildcard:
+		switch val := v.(type) {
+		case []interface{}:
+			return val, nil
+		case map[string]interface{}:
+			out := make([]interface{}, 0, len(val))
+			for _, key := range sortedKeys(val) {
+				out = append(out, val[key])
+			}
+			return out, nil
+		case nil:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %s", typeName(v))
+		}
+	case step.isIndex:
+		switch val := v.(type) {
+		case []interface{}:
+			i := step.index
+			if i < 0 {
+				i += len(val)
+			}
+			if i < 0 || i >= len(val) {
+				return []interface{}{nil}, nil
+			}
+			return []interface{}{val[i]}, nil
+		case nil:
+			return []interface{}{nil}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with [%d]", typeName(v), step.index)
+		}
+	default:
+		switch val := v.(type) {
+		case map[string]interface{}:
+			return []interface{}{val[step.key]}, nil
+		case nil:
+			return []interface{}{nil}, nil
+		default:
+			return nil, fmt.Errorf("cannot read field %q of %s", step.key, typeName(v))
+		}
+	}
+}
+
+func parseQuery(query string) ([]queryStep, error) {
+	q := strings.TrimSpace(query)
+	q = strings.TrimPrefix(q, "$")
+
+	var steps []queryStep
+	for i := 0; i < len(q); {
+		switch q[i] {
+		case '.':
+			i++
+			if i >= len(q) || q[i] == '[' {
+				continue
+			}
+			if q[i] == '*' {
+				steps = append(steps, queryStep{wildcard: true})
+				i++
+				continue
+			}
+			start := i
+			for i < len(q) && q[i] != '.' && q[i] != '[' {
+				i++
+			}
+			steps = append(steps, queryStep{key: q[start:i]})
+		case '[':
+			end := strings.IndexByte(q[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing ']'", query)
+			}
+			inner := strings.TrimSpace(q[i+1 : i+end])
+			i += end + 1
+
+			switch {
+			case inner == "*" || inner == "":
+				steps = append(steps, queryStep{wildcard: true})
+			case strings.HasPrefix(inner, `"`) || strings.HasPrefix(inner, "'"):
+				key := strings.Trim(inner, `"'`)
+				steps = append(steps, queryStep{key: key})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid query %q: bad index [%s]", query, inner)
+				}
+				steps = append(steps, queryStep{index: n, isIndex: true})
+			}
+		default:
+			// Allow a bare leading field name such as "artifact.recordingUrl"
+			if len(steps) > 0 {
+				return nil, fmt.Errorf("invalid query %q at position %d", query, i)
+			}
+			q = "." + q[i:]
+			i = 0
+		}
+	}
+	return steps, nil
+}
+
+// ExecuteTemplate renders data with a Go text/template. Templates see the
+// original values, so SDK results use Go field names such as {{.Id}} while
+// raw API maps use JSON keys such as {{.id}}. A key missing from a map is
+// an error rather than "<no value>", so a template written for the other
+// shape fails loudly.
+func ExecuteTemplate(w io.Writer, text string, data interface{}) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// writeQueryResult prints a query result. Scalars and lists of scalars are
+// printed raw, one per line, unless a structured format was requested.
+func writeQueryResult(w io.Writer, format OutputFormat, result interface{}) error {
+	if format == "" || format == FormatTable {
+		if isScalar(result) {
+			_, err := fmt.Fprintln(w, FormatValue(result))
+			return err
+		}
+		if list, ok := result.([]interface{}); ok && allScalars(list) {
+			for _, item := range list {
+				if _, err := fmt.Fprintln(w, FormatValue(item)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if format == "" {
+			format = FormatJSON
+		}
+	}
+	return Write(w, format, result, nil)
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+func allScalars(list []interface{}) bool {
+	for _, item := range list {
+		if !isScalar(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
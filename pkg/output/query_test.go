@@ -0,0 +1,100 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type call struct {
+	Id       string            `json:"id"`
+	Artifact map[string]string `json:"artifact,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+}
+
+func TestQuery(t *testing.T) {
+	calls := []call{
+		{Id: "c1", Artifact: map[string]string{"recordingUrl": "https://r/1"}, Tags: []string{"a", "b"}},
+		{Id: "c2", Tags: []string{"c"}},
+	}
+
+	tests := []struct {
+		name     string
+		data     interface{}
+		query    string
+		expected interface{}
+		hasError bool
+	}{
+		{name: "nested field", data: calls[0], query: ".artifact.recordingUrl", expected: "https://r/1"},
+		{name: "dollar prefix", data: calls[0], query: "$.id", expected: "c1"},
+		{name: "bare field", data: calls[0], query: "artifact.recordingUrl", expected: "https://r/1"},
+		{name: "quoted key", data: calls[0], query: `.artifact["recordingUrl"]`, expected: "https://r/1"},
+		{name: "missing field is null", data: calls[1], query: ".artifact.recordingUrl", expected: nil},
+		{name: "index", data: calls, query: ".[1].id", expected: "c2"},
+		{name: "negative index", data: calls, query: "[-1].tags[0]", expected: "c"},
+		{name: "wildcard", data: calls, query: ".[*].id", expected: []interface{}{"c1", "c2"}},
+		{name: "nested wildcard", data: calls, query: ".[*].tags[*]", expected: []interface{}{"a", "b", "c"}},
+		{name: "identity", data: calls[1], query: ".", expected: map[string]interface{}{"id": "c2", "tags": []interface{}{"c"}}},
+		{name: "index into object", data: calls[0], query: ".id[0]", hasError: true},
+		{name: "unterminated bracket", data: calls, query: ".[0", hasError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Query(tt.data, tt.query)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestWriteQueryResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   OutputFormat
+		result   interface{}
+		expected string
+	}{
+		{name: "scalar is raw", result: "https://r/1", expected: "https://r/1\n"},
+		{name: "scalar list one per line", result: []interface{}{"c1", json.Number("2")}, expected: "c1\n2\n"},
+		{name: "object defaults to json", result: map[string]interface{}{"id": "c1"}, expected: "{\n  \"id\": \"c1\"\n}\n"},
+		{name: "explicit json keeps quotes", format: FormatJSON, result: "c1", expected: "\"c1\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			require.NoError(t, writeQueryResult(buf, tt.format, tt.result))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestExecuteTemplate(t *testing.T) {
+	calls := []call{{Id: "c1"}, {Id: "c2"}}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, ExecuteTemplate(buf, `{{range .}}{{.Id}}{{"\n"}}{{end}}`, calls))
+	assert.Equal(t, "c1\nc2\n", buf.String())
+
+	// Raw API maps from the fallback path keep their JSON keys
+	raw := []interface{}{map[string]interface{}{"id": "c1"}, map[string]interface{}{"id": "c2"}}
+	buf.Reset()
+	require.NoError(t, ExecuteTemplate(buf, `{{range .}}{{.id}}{{"\n"}}{{end}}`, raw))
+	assert.Equal(t, "c1\nc2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, ExecuteTemplate(buf, `{{json .}}`, calls[0]))
+	assert.Equal(t, `{"id":"c1"}`, buf.String())
+
+	assert.Error(t, ExecuteTemplate(buf, `{{.Id`, calls))
+	assert.Error(t, ExecuteTemplate(buf, `{{range .}}{{.Id}}{{end}}`, raw))
+	assert.Error(t, ExecuteTemplate(buf, `{{range .}}{{.Name}}{{end}}`, calls))
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// tableWindow is how many table rows are held to size the columns. Later
+// rows are written as they arrive, padded to those widths.
+const tableWindow = 100
+
+// Stream renders list items one at a time as pages arrive, so long lists
+// never have to be held in memory. The output is identical to calling
+// Render with the full list.
+type Stream struct {
+	w       io.Writer
+	format  OutputFormat
+	headers []string
+	count   int
+	closed  bool
+
+	// Table rows are held until tableWindow of them fix the column widths
+	pending [][]string
+	widths  []int
+
+	csv *csv.Writer
+
+	// --query and --template operate on the whole list, so items are
+	// buffered and rendered on Close instead
+	buffered bool
+	items    []interface{}
+	rows     [][]string
+}
+
+// NewStream creates a stream writing to stdout in the global format.
+// headers describe the table and CSV columns.
+func NewStream(headers []string) *Stream {
+	format := currentFormat
+	if format == "" {
+		format = FormatTable
+	}
+	s := newStream(os.Stdout, format, headers)
+	s.buffered = currentQuery != "" || currentTemplate != ""
+	return s
+}
+
+func newStream(w io.Writer, format OutputFormat, headers []string) *Stream {
+	return &Stream{w: w, format: format, headers: headers}
+}
+
+// Add renders one item. row is its table view and must match the headers.
+func (s *Stream) Add(item interface{}, row []string) error {
+	s.count++
+
+	if s.buffered {
+		s.items = append(s.items, item)
+		s.rows = append(s.rows, row)
+		return nil
+	}
+
+	switch s.format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		prefix := ",\n  "
+		if s.count == 1 {
+			prefix = "[\n  "
+		}
+		_, err = fmt.Fprintf(s.w, "%s%s", prefix, b)
+		return err
+	case FormatYAML:
+		// A one-element list per item concatenates into a single YAML list
+		return writeYAML(s.w, []interface{}{item})
+	case FormatCSV:
+		if s.csv == nil {
+			s.csv = csv.NewWriter(s.w)
+			if err := s.csv.Write(s.headers); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+		if err := s.csv.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+	case FormatTable:
+		if s.widths != nil {
+			return s.writeRow(row)
+		}
+		s.pending = append(s.pending, row)
+		if len(s.pending) < tableWindow {
+			return nil
+		}
+		return s.flushTable()
+	default:
+		return fmt.Errorf("unsupported output format: %s", s.format)
+	}
+}
+
+// Count returns the number of items added so far
+func (s *Stream) Count() int {
+	return s.count
+}
+
+// Close finishes the output. An empty table prints nothing so commands can
+// show their own hint instead.
+func (s *Stream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if s.buffered {
+		items := s.items
+		if items == nil {
+			items = []interface{}{}
+		}
+		return Render(items, &Table{Headers: s.headers, Rows: s.rows})
+	}
+
+	switch s.format {
+	case FormatJSON:
+		if s.count == 0 {
+			_, err := fmt.Fprintln(s.w, "[]")
+			return err
+		}
+		_, err := fmt.Fprintln(s.w, "\n]")
+		return err
+	case FormatYAML:
+		if s.count == 0 {
+			_, err := fmt.Fprintln(s.w, "[]")
+			return err
+		}
+	case FormatCSV:
+		if s.csv == nil {
+			return writeCSV(s.w, s.headers, nil)
+		}
+		s.csv.Flush()
+		return s.csv.Error()
+	case FormatTable:
+		if s.widths == nil && len(s.pending) > 0 {
+			return s.flushTable()
+		}
+	}
+	return nil
+}
+
+// Abort ends a stream after an error. Output already written is terminated
+// so it stays valid, such as closing an open JSON array, while buffered
+// items are dropped. It does nothing once the stream is closed, so it can
+// be deferred alongside Close.
+func (s *Stream) Abort() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	switch {
+	case s.buffered:
+	case s.format == FormatJSON && s.count > 0:
+		fmt.Fprintln(s.w, "\n]")
+	case s.format == FormatCSV && s.csv != nil:
+		s.csv.Flush()
+	case s.format == FormatTable && s.widths == nil && len(s.pending) > 0:
+		_ = s.flushTable()
+	}
+}
+
+// flushTable writes the header and held rows aligned by tabwriter, and
+// remembers the column widths for the rows that follow
+func (s *Stream) flushTable() error {
+	rows := append([][]string{s.headers}, s.pending...)
+	s.pending = nil
+
+	s.widths = make([]int, len(s.headers))
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(s.widths) {
+				s.widths[i] = max(s.widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(s.w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// writeRow writes one table row padded to the widths of the first rows. A
+// longer cell pushes the rest of its row right rather than holding output.
+func (s *Stream) writeRow(row []string) error {
+	var b strings.Builder
+	for i, cell := range row {
+		b.WriteString(cell)
+		if i == len(row)-1 {
+			break
+		}
+		pad := 2
+		if i < len(s.widths) {
+			pad += s.widths[i] - utf8.RuneCountInString(cell)
+		}
+		b.WriteString(strings.Repeat(" ", max(pad, 2)))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamMatchesWrite(t *testing.T) {
+	items := []sample{{ID: "a1", Name: "Alpha", Score: 1.5}, {ID: "b2", Name: "Beta", Score: 2}}
+	table := &Table{
+		Headers: []string{"ID", "NAME"},
+		Rows:    [][]string{{"a1", "Alpha"}, {"b2", "Beta"}},
+	}
+
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			expected := new(bytes.Buffer)
+			require.NoError(t, Write(expected, format, items, table))
+
+			streamed := new(bytes.Buffer)
+			s := newStream(streamed, format, table.Headers)
+			for i, item := range items {
+				require.NoError(t, s.Add(item, table.Rows[i]))
+			}
+			require.NoError(t, s.Close())
+
+			assert.Equal(t, expected.String(), streamed.String())
+			assert.Equal(t, 2, s.Count())
+		})
+	}
+}
+
+func TestStreamEmpty(t *testing.T) {
+	tests := map[OutputFormat]string{
+		FormatJSON:  "[]\n",
+		FormatYAML:  "[]\n",
+		FormatCSV:   "ID\n",
+		FormatTable: "",
+	}
+
+	for format, expected := range tests {
+		t.Run(string(format), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			require.NoError(t, newStream(buf, format, []string{"ID"}).Close())
+			assert.Equal(t, expected, buf.String())
+		})
+	}
+}
+
+func TestStreamTableBeyondWindow(t *testing.T) {
+	headers := []string{"ID", "NAME", "CREATED"}
+	var rows [][]string
+	for i := 0; i < tableWindow+5; i++ {
+		rows = append(rows, []string{fmt.Sprintf("id-%03d", i), "assistant", "2025-01-01"})
+	}
+
+	expected := new(bytes.Buffer)
+	require.NoError(t, Write(expected, FormatTable, nil, &Table{Headers: headers, Rows: rows}))
+
+	streamed := new(bytes.Buffer)
+	s := newStream(streamed, FormatTable, headers)
+	for i, row := range rows {
+		require.NoError(t, s.Add(i, row))
+		if i == tableWindow-1 {
+			// The first window is written without waiting for Close
+			assert.Equal(t, tableWindow+1, strings.Count(streamed.String(), "\n"))
+		}
+	}
+	require.NoError(t, s.Close())
+	assert.Equal(t, expected.String(), streamed.String())
+
+	// A cell wider than the first window's pushes its row right
+	s.widths = []int{2, 4, 7}
+	buf := new(bytes.Buffer)
+	s.w = buf
+	require.NoError(t, s.writeRow([]string{"long-id", "x", "y"}))
+	assert.Equal(t, "long-id  x     y\n", buf.String())
+}
+
+func TestStreamAbort(t *testing.T) {
+	buf := new(bytes.Buffer)
+	s := newStream(buf, FormatJSON, []string{"ID"})
+	require.NoError(t, s.Add(map[string]string{"id": "a"}, []string{"a"}))
+	s.Abort()
+
+	var items []map[string]string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+	assert.Len(t, items, 1)
+
+	// Abort after Close writes nothing more
+	buf.Reset()
+	s = newStream(buf, FormatJSON, []string{"ID"})
+	require.NoError(t, s.Close())
+	s.Abort()
+	assert.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,287 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is what an Op does at its path
+type Kind int
+
+const (
+	Set    Kind = iota // Replace the value, creating parent objects as needed
+	Unset              // Remove the key or array element
+	Append             // Add the value to the end of an array
+)
+
+// Op is one change to a loosely typed document, such as a resource fetched
+// from the API
+type Op struct {
+	Kind  Kind
+	Path  string // Dotted path with array indexes, e.g. "model.messages[0].content"
+	Value interface{}
+}
+
+// step is one segment of a path: an object key or an array index
+type step struct {
+	key   string
+	index int
+	isIdx bool
+}
+
+func (s step) String() string {
+	if s.isIdx {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return s.key
+}
+
+// ParseValue infers the type of a command-line value. Numbers, booleans,
+// null and JSON objects, arrays or quoted strings are decoded; anything else
+// is kept as a plain string. Numbers decode as float64, the type the API's
+// own values have, so setting a field to its current value is no change.
+func ParseValue(raw string) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return raw
+	}
+	return v
+}
+
+// Apply returns a deep copy of doc with ops applied in order. doc itself is
+// left untouched, so it can be compared with the result. The result holds
+// plain JSON types only, whatever types the op values had.
+func Apply(doc map[string]interface{}, ops []Op) (map[string]interface{}, error) {
+	out, err := clone(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		steps, err := parsePath(op.Path)
+		if err != nil {
+			return nil, err
+		}
+		var updated interface{}
+		switch op.Kind {
+		case Set:
+			updated, err = set(out, steps, op.Value, op.Path, "")
+		case Unset:
+			updated, err = unset(out, steps, op.Path)
+		case Append:
+			updated, err = appendAt(out, steps, op.Value, op.Path)
+		default:
+			err = fmt.Errorf("unknown patch operation %d", op.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = updated.(map[string]interface{})
+	}
+	return clone(out)
+}
+
+// parsePath splits "a.b[0].c" into its keys and indexes
+func parsePath(path string) ([]step, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty field path")
+	}
+	var steps []step
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && (len(steps) == 0 || rest == "") {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+		if key != "" {
+			steps = append(steps, step{key: key})
+		}
+		for rest != "" {
+			num, after, ok := strings.Cut(rest, "]")
+			index, err := strconv.Atoi(num)
+			if !ok || err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid array index in field path %q", path)
+			}
+			steps = append(steps, step{index: index, isIdx: true})
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid field path %q", path)
+			}
+			rest = after[1:]
+		}
+	}
+	return steps, nil
+}
+
+// set stores value at steps below cur and returns the updated container.
+// Missing objects are created; arrays can be extended by one element.
+// parent is the path walked so far, used in errors.
+func set(cur interface{}, steps []step, value interface{}, path, parent string) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	s := steps[0]
+	if !s.isIdx {
+		obj, ok := cur.(map[string]interface{})
+		if cur == nil {
+			obj, ok = map[string]interface{}{}, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot set %s: %s is not an object", path, parent)
+		}
+		next := s.key
+		if parent != "" {
+			next = parent + "." + s.key
+		}
+		child, err := set(obj[s.key], steps[1:], value, path, next)
+		if err != nil {
+			return nil, err
+		}
+		obj[s.key] = child
+		return obj, nil
+	}
+
+	list, ok := cur.([]interface{})
+	if cur == nil {
+		list, ok = []interface{}{}, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("cannot set %s: %s is not an array", path, parent)
+	}
+	next := parent + s.String()
+	switch {
+	case s.index < len(list):
+		child, err := set(list[s.index], steps[1:], value, path, next)
+		if err != nil {
+			return nil, err
+		}
+		list[s.index] = child
+	case s.index == len(list):
+		child, err := set(nil, steps[1:], value, path, next)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, child)
+	default:
+		return nil, fmt.Errorf("cannot set %s: index %d is past the end of an array of %d", path, s.index, len(list))
+	}
+	return list, nil
+}
+
+// unset removes the value at steps below cur. Missing values are left as
+// they are.
+func unset(cur interface{}, steps []step, path string) (interface{}, error) {
+	s := steps[0]
+	last := len(steps) == 1
+	switch container := cur.(type) {
+	case map[string]interface{}:
+		if s.isIdx {
+			return nil, fmt.Errorf("cannot unset %s: %s is an object, not an array", path, s)
+		}
+		child, ok := container[s.key]
+		if !ok {
+			return container, nil
+		}
+		if last {
+			delete(container, s.key)
+			return container, nil
+		}
+		updated, err := unset(child, steps[1:], path)
+		if err != nil {
+			return nil, err
+		}
+		container[s.key] = updated
+		return container, nil
+	case []interface{}:
+		if !s.isIdx {
+			return nil, fmt.Errorf("cannot unset %s: %s is an array, not an object", path, s.key)
+		}
+		if s.index >= len(container) {
+			return container, nil
+		}
+		if last {
+			return append(container[:s.index], container[s.index+1:]...), nil
+		}
+		updated, err := unset(container[s.index], steps[1:], path)
+		if err != nil {
+			return nil, err
+		}
+		container[s.index] = updated
+		return container, nil
+	default:
+		return cur, nil
+	}
+}
+
+// appendAt adds value to the array at steps below cur, creating the array
+// if it doesn't exist yet
+func appendAt(cur interface{}, steps []step, value interface{}, path string) (interface{}, error) {
+	var existing interface{}
+	if obj, err := get(cur, steps); err == nil {
+		existing = obj
+	}
+	var list []interface{}
+	switch v := existing.(type) {
+	case nil:
+	case []interface{}:
+		list = v
+	default:
+		return nil, fmt.Errorf("cannot append to %s: it is not an array", path)
+	}
+	return set(cur, steps, append(list, value), path, "")
+}
+
+// get returns the value at steps below cur
+func get(cur interface{}, steps []step) (interface{}, error) {
+	for _, s := range steps {
+		switch container := cur.(type) {
+		case map[string]interface{}:
+			cur = container[s.key]
+		case []interface{}:
+			if !s.isIdx || s.index >= len(container) {
+				return nil, fmt.Errorf("no value at %s", s)
+			}
+			cur = container[s.index]
+		default:
+			return nil, fmt.Errorf("no value at %s", s)
+		}
+	}
+	return cur, nil
+}
+
+// clone deep-copies a JSON document
+func clone(doc map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy document: %w", err)
+	}
+	// Numbers decode as float64 again so unchanged values compare equal to
+	// the original
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("failed to copy document: %w", err)
+	}
+	return out, nil
+}
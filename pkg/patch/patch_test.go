@@ -0,0 +1,85 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue(t *testing.T) {
+	assert.Equal(t, 0.3, ParseValue("0.3"))
+	assert.Equal(t, float64(42), ParseValue("42"))
+	assert.Equal(t, true, ParseValue("true"))
+	assert.Nil(t, ParseValue("null"))
+	assert.Equal(t, "42", ParseValue(`"42"`))
+	assert.Equal(t, []interface{}{"a"}, ParseValue(`["a"]`))
+	assert.Equal(t, "hello world", ParseValue("hello world"))
+	assert.Equal(t, "1 2", ParseValue("1 2"))
+}
+
+func TestApply(t *testing.T) {
+	doc := map[string]interface{}{
+		"name":  "support",
+		"voice": map[string]interface{}{"provider": "vapi", "speed": 1.1},
+		"model": map[string]interface{}{
+			"temperature": 0.7,
+			"messages":    []interface{}{map[string]interface{}{"role": "system", "content": "old"}},
+			"toolIds":     []interface{}{"t1"},
+		},
+	}
+
+	out, err := Apply(doc, []Op{
+		{Kind: Set, Path: "model.temperature", Value: json.Number("0.3")},
+		{Kind: Set, Path: "model.messages[0].content", Value: "new"},
+		{Kind: Set, Path: "analysisPlan.summaryPlan.enabled", Value: true},
+		{Kind: Unset, Path: "voice.speed"},
+		{Kind: Unset, Path: "voice.missing.deeper"},
+		{Kind: Append, Path: "model.toolIds", Value: "t2"},
+		{Kind: Append, Path: "serverMessages", Value: "end-of-call-report"},
+	})
+	require.NoError(t, err)
+
+	model := out["model"].(map[string]interface{})
+	assert.Equal(t, 0.3, model["temperature"])
+	assert.Equal(t, "new", model["messages"].([]interface{})[0].(map[string]interface{})["content"])
+	assert.Equal(t, []interface{}{"t1", "t2"}, model["toolIds"])
+	assert.Equal(t, map[string]interface{}{"provider": "vapi"}, out["voice"])
+	assert.Equal(t, true, out["analysisPlan"].(map[string]interface{})["summaryPlan"].(map[string]interface{})["enabled"])
+	assert.Equal(t, []interface{}{"end-of-call-report"}, out["serverMessages"])
+
+	// The input is not modified
+	assert.Equal(t, 0.7, doc["model"].(map[string]interface{})["temperature"])
+	assert.Equal(t, []interface{}{"t1"}, doc["model"].(map[string]interface{})["toolIds"])
+	assert.Contains(t, doc["voice"], "speed")
+}
+
+func TestApplySameValueIsNoChange(t *testing.T) {
+	doc := map[string]interface{}{"model": map[string]interface{}{"temperature": 0.7, "maxTokens": float64(250)}}
+
+	out, err := Apply(doc, []Op{
+		{Kind: Set, Path: "model.temperature", Value: ParseValue("0.7")},
+		{Kind: Set, Path: "model.maxTokens", Value: json.Number("250")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, doc, out)
+}
+
+func TestApplyErrors(t *testing.T) {
+	doc := map[string]interface{}{"name": "support", "toolIds": []interface{}{"t1"}}
+
+	_, err := Apply(doc, []Op{{Kind: Set, Path: "name.first", Value: "x"}})
+	assert.EqualError(t, err, "cannot set name.first: name is not an object")
+
+	_, err = Apply(doc, []Op{{Kind: Set, Path: "toolIds[5]", Value: "x"}})
+	assert.ErrorContains(t, err, "past the end")
+
+	_, err = Apply(doc, []Op{{Kind: Append, Path: "name", Value: "x"}})
+	assert.ErrorContains(t, err, "not an array")
+
+	for _, path := range []string{"", ".a", "a..b", "a[x]", "a[1", "a[0]b"} {
+		_, err = Apply(doc, []Op{{Kind: Set, Path: path, Value: 1}})
+		assert.Error(t, err, path)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	vapi "github.com/VapiAI/server-sdk-go"
+)
+
+// Kind pairs a resource with the SDK request types for creating and
+// updating it
+type Kind struct {
+	Name   string // Matches the resource's API path, e.g. "phone-number"
+	Create reflect.Type
+	Update reflect.Type
+}
+
+// Kinds lists every resource whose payloads can be validated
+var Kinds = []Kind{
+	{Name: "assistant", Create: reflect.TypeOf(vapi.CreateAssistantDto{}), Update: reflect.TypeOf(vapi.UpdateAssistantDto{})},
+	{Name: "tool", Create: reflect.TypeOf(vapi.ToolsCreateRequest{}), Update: reflect.TypeOf(vapi.ToolsUpdateRequest{})},
+	{Name: "workflow", Create: reflect.TypeOf(vapi.CreateWorkflowDto{}), Update: reflect.TypeOf(vapi.UpdateWorkflowDto{})},
+	{Name: "phone-number", Create: reflect.TypeOf(vapi.PhoneNumbersCreateRequest{}), Update: reflect.TypeOf(vapi.PhoneNumbersUpdateRequest{})},
+	{Name: "campaign", Create: reflect.TypeOf(vapi.CreateCampaignDto{}), Update: reflect.TypeOf(vapi.UpdateCampaignDto{})},
+	{Name: "squad", Create: reflect.TypeOf(vapi.CreateSquadDto{}), Update: reflect.TypeOf(vapi.UpdateSquadDto{})},
+}
+
+// Lookup finds a kind by name
+func Lookup(name string) (Kind, error) {
+	names := make([]string, len(Kinds))
+	for i, k := range Kinds {
+		if k.Name == name {
+			return k, nil
+		}
+		names[i] = k.Name
+	}
+	return Kind{}, fmt.Errorf("unknown kind %q (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// Schema returns the schema of the kind's create or update payload
+func (k Kind) Schema(update bool) *Schema {
+	if update {
+		return For(k.Update)
+	}
+	return For(k.Create)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package schema
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Locate fills in the line and column of each error from the document's
+// source. JSON is parsed as YAML, so both formats work. Errors about fields
+// that aren't in the source, such as missing required fields, point at the
+// nearest enclosing value that is.
+func Locate(errs []Error, source []byte) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(source, &root); err != nil || len(root.Content) == 0 {
+		return
+	}
+	for i := range errs {
+		node := find(root.Content[0], errs[i].Pointer, errs[i].onKey)
+		errs[i].Line, errs[i].Column = node.Line, node.Column
+	}
+}
+
+// find walks pointer from node and returns the deepest node it reaches.
+// With onKey, the key of the last step is returned instead of its value.
+func find(node *yaml.Node, pointer string, onKey bool) *yaml.Node {
+	if pointer == "" {
+		return node
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		token = unescape(token)
+		last := i == len(tokens)-1
+		switch node.Kind {
+		case yaml.MappingNode:
+			next := -1
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == token {
+					next = j
+				}
+			}
+			if next < 0 {
+				return node
+			}
+			if last && onKey {
+				return node.Content[next]
+			}
+			node = node.Content[next+1]
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return node
+			}
+			node = node.Content[index]
+		default:
+			return node
+		}
+	}
+	return node
+}
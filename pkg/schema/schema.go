@@ -0,0 +1,239 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Type is the JSON type a Schema accepts
+type Type string
+
+const (
+	TypeAny     Type = ""
+	TypeObject  Type = "object"
+	TypeArray   Type = "array"
+	TypeString  Type = "string"
+	TypeNumber  Type = "number"
+	TypeInteger Type = "integer"
+	TypeBoolean Type = "boolean"
+)
+
+// Schema is the subset of JSON Schema that the server SDK's request types
+// can express: typed properties, required fields, arrays, maps and unions.
+// Provider and type discriminators are kept as Const values.
+type Schema struct {
+	Name       string // SDK type name, used in messages
+	Type       Type
+	Properties map[string]*Schema
+	Required   []string
+	Const      map[string]interface{} // Literal fields, e.g. {"provider": "openai"}
+	Items      *Schema                // Array elements
+	Values     *Schema                // Map values; nil for fixed objects
+	OneOf      []*Schema              // Union variants
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[reflect.Type]*Schema{}
+)
+
+// For generates the schema of an SDK request type from its Go definition.
+// JSON field names come from the struct tags; fields without omitempty are
+// required. Unions, which the SDK models as structs of untagged variant
+// pointers, become OneOf.
+func For(t reflect.Type) *Schema {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	return build(t)
+}
+
+func build(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := cache[t]; ok {
+		return s
+	}
+
+	s := &Schema{Name: t.Name()}
+	switch t.Kind() {
+	case reflect.String:
+		s.Type = TypeString
+	case reflect.Bool:
+		s.Type = TypeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = TypeInteger
+	case reflect.Float32, reflect.Float64:
+		s.Type = TypeNumber
+	case reflect.Slice, reflect.Array:
+		if t == reflect.TypeOf(json.RawMessage{}) {
+			break
+		}
+		s.Type = TypeArray
+		cache[t] = s
+		s.Items = build(t.Elem())
+	case reflect.Map:
+		s.Type = TypeObject
+		cache[t] = s
+		s.Values = build(t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			s.Type = TypeString
+			break
+		}
+		// Register before recursing so self-referencing types terminate
+		cache[t] = s
+		buildStruct(s, t)
+	}
+	cache[t] = s
+	return s
+}
+
+func buildStruct(s *Schema, t reflect.Type) {
+	var variants []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup("json")
+		if !hasTag {
+			variants = append(variants, field.Type)
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if s.Properties == nil {
+			s.Properties = make(map[string]*Schema)
+		}
+		s.Properties[name] = build(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	if s.Properties == nil && len(variants) > 0 {
+		for _, v := range variants {
+			s.OneOf = append(s.OneOf, build(v))
+		}
+		return
+	}
+	if s.Properties == nil {
+		// An opaque SDK type with no public fields accepts anything
+		return
+	}
+
+	s.Type = TypeObject
+	s.Const = literals(t, s.Properties)
+	for name := range s.Const {
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+}
+
+// literals finds the discriminator fields the SDK writes itself, such as
+// "provider": "openai", by encoding an empty value of t
+func literals(t reflect.Type, properties map[string]*Schema) map[string]interface{} {
+	b, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return nil
+	}
+	var encoded map[string]interface{}
+	if err := json.Unmarshal(b, &encoded); err != nil {
+		return nil
+	}
+	var consts map[string]interface{}
+	for name, value := range encoded {
+		if _, ok := properties[name]; ok {
+			continue
+		}
+		if consts == nil {
+			consts = make(map[string]interface{})
+		}
+		consts[name] = value
+	}
+	return consts
+}
+
+// hasProperty reports whether s, or any of its union variants, accepts
+// name as an object key
+func (s *Schema) hasProperty(name string) bool {
+	if _, ok := s.Properties[name]; ok {
+		return true
+	}
+	if _, ok := s.Const[name]; ok {
+		return true
+	}
+	for _, v := range s.OneOf {
+		if v.hasProperty(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyNames lists every key s accepts, including those of its variants
+func (s *Schema) propertyNames() []string {
+	seen := map[string]bool{}
+	var collect func(*Schema)
+	collect = func(s *Schema) {
+		for name := range s.Properties {
+			seen[name] = true
+		}
+		for name := range s.Const {
+			seen[name] = true
+		}
+		for _, v := range s.OneOf {
+			collect(v)
+		}
+	}
+	collect(s)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// property returns the schema of key name in s, looking through union
+// variants
+func (s *Schema) property(name string) *Schema {
+	if p, ok := s.Properties[name]; ok {
+		return p
+	}
+	for _, v := range s.OneOf {
+		if p := v.property(name); p != nil {
+			return p
+		}
+	}
+	return nil
+}
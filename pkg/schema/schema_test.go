@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, src string) interface{} {
+	t.Helper()
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(src), &v))
+	return v
+}
+
+func TestValidateAssistant(t *testing.T) {
+	kind, err := Lookup("assistant")
+	require.NoError(t, err)
+
+	valid := `{
+  "name": "support",
+  "firstMessage": "Hi",
+  "model": {"provider": "openai", "model": "gpt-4o", "temperature": 0.3,
+            "messages": [{"role": "system", "content": "Be brief"}]},
+  "voice": {"provider": "vapi", "voiceId": "Elliot"},
+  "metadata": {"team": "support"}
+}`
+	assert.Empty(t, kind.Schema(false).Validate(decode(t, valid)))
+	assert.Empty(t, kind.Schema(true).Validate(decode(t, `{"voicemailMessage": null}`)))
+
+	src := `{
+  "name": "support",
+  "voiceId": "Elliot",
+  "firstMesage": "Hi",
+  "silenceTimeoutSeconds": "30",
+  "model": {"provider": "opnai", "model": "gpt-4o"}
+}`
+	errs := kind.Schema(false).Validate(decode(t, src))
+	Locate(errs, []byte(src))
+	require.Len(t, errs, 4)
+
+	assert.Equal(t, "/firstMesage", errs[0].Pointer)
+	assert.Equal(t, "firstMessage", errs[0].Suggestion)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, 3, errs[0].Column)
+
+	assert.Equal(t, "/model/provider", errs[1].Pointer)
+	assert.Equal(t, "openai", errs[1].Suggestion)
+	assert.Equal(t, 6, errs[1].Line)
+
+	assert.Equal(t, "/silenceTimeoutSeconds", errs[2].Pointer)
+	assert.Equal(t, "expected number, got string", errs[2].Message)
+
+	assert.Equal(t, "/voiceId", errs[3].Pointer)
+	assert.Equal(t, "voice.voiceId", errs[3].Suggestion)
+	assert.Equal(t, 3, errs[3].Line)
+
+	verr := &ValidationError{Source: "assistant.json", Errors: errs}
+	assert.Contains(t, verr.Error(), `assistant.json:4:3: /firstMesage: unknown property "firstMesage" (did you mean "firstMessage"?)`)
+}
+
+func TestValidateUnionAndRequired(t *testing.T) {
+	kind, err := Lookup("tool")
+	require.NoError(t, err)
+
+	assert.Empty(t, kind.Schema(true).Validate(decode(t, `{"function": {"name": "lookup"}}`)))
+	errs := kind.Schema(false).Validate(decode(t, `{"type": "functon"}`))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "function", errs[0].Suggestion)
+
+	_, err = Lookup("bogus")
+	assert.ErrorContains(t, err, "valid: assistant, tool")
+}
+
+func TestPointerEscaping(t *testing.T) {
+	errs := []Error{{Pointer: "/metadata/a~1b", Message: "x"}}
+	Locate(errs, []byte("metadata:\n  a/b: 1\n"))
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, "a/b", unescape("a~1b"))
+	assert.Equal(t, 1, levenshtein("voice", "voices"))
+}
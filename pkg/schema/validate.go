@@ -0,0 +1,329 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error is one problem found in a document
+type Error struct {
+	Pointer    string `json:"pointer"` // RFC 6901 JSON pointer; empty for the whole document
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"` // Likely intended field, as a dotted path
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
+
+	onKey bool // The problem is the key at Pointer rather than its value
+}
+
+func (e Error) String() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	msg := pointer + ": " + e.Message
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// ValidationError is returned when a payload doesn't match its schema
+type ValidationError struct {
+	Source string // File name, or a description such as "--json"
+	Errors []Error
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is not a valid payload (%d problem(s)):", e.Source, len(e.Errors))
+	for _, err := range e.Errors {
+		b.WriteString("\n  ")
+		if err.Line > 0 {
+			fmt.Fprintf(&b, "%s:%d:%d: ", e.Source, err.Line, err.Column)
+		}
+		b.WriteString(err.String())
+	}
+	return b.String()
+}
+
+// Validate checks a decoded JSON document against s. Null is accepted for
+// any optional field, since PATCH bodies use it to clear a value.
+func (s *Schema) Validate(doc interface{}) []Error {
+	errs := s.validate(doc, "")
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Pointer < errs[j].Pointer })
+	return errs
+}
+
+func (s *Schema) validate(v interface{}, ptr string) []Error {
+	if v == nil {
+		return nil
+	}
+	if len(s.OneOf) > 0 {
+		return s.validateUnion(v, ptr)
+	}
+
+	switch s.Type {
+	case TypeObject:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return []Error{mismatch(ptr, s.Type, v)}
+		}
+		return s.validateObject(obj, ptr)
+	case TypeArray:
+		list, ok := v.([]interface{})
+		if !ok {
+			return []Error{mismatch(ptr, s.Type, v)}
+		}
+		var errs []Error
+		for i, item := range list {
+			errs = append(errs, s.Items.validate(item, ptr+"/"+strconv.Itoa(i))...)
+		}
+		return errs
+	case TypeString:
+		if _, ok := v.(string); !ok {
+			return []Error{mismatch(ptr, s.Type, v)}
+		}
+	case TypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return []Error{mismatch(ptr, s.Type, v)}
+		}
+	case TypeNumber, TypeInteger:
+		n, ok := number(v)
+		if !ok {
+			return []Error{mismatch(ptr, s.Type, v)}
+		}
+		if s.Type == TypeInteger && n != math.Trunc(n) {
+			return []Error{{Pointer: ptr, Message: fmt.Sprintf("expected an integer, got %v", n)}}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(obj map[string]interface{}, ptr string) []Error {
+	var errs []Error
+	for _, key := range sortedKeys(obj) {
+		value := obj[key]
+		child := ptr + "/" + escape(key)
+		if s.Values != nil {
+			errs = append(errs, s.Values.validate(value, child)...)
+			continue
+		}
+		if want, ok := s.Const[key]; ok {
+			if value != want {
+				errs = append(errs, Error{Pointer: child, Message: fmt.Sprintf("must be %s for %s", describe(want), s.Name)})
+			}
+			continue
+		}
+		prop, ok := s.Properties[key]
+		if !ok {
+			errs = append(errs, Error{
+				Pointer:    child,
+				Message:    fmt.Sprintf("unknown property %q", key),
+				Suggestion: s.suggest(key),
+				onKey:      true,
+			})
+			continue
+		}
+		errs = append(errs, prop.validate(value, child)...)
+	}
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			errs = append(errs, Error{Pointer: ptr, Message: fmt.Sprintf("missing required property %q", name)})
+		}
+	}
+	return errs
+}
+
+// validateUnion picks the variant v was meant to be. Variants with
+// discriminators, such as a model's provider, are chosen by that field;
+// otherwise the variant with the fewest errors is reported.
+func (s *Schema) validateUnion(v interface{}, ptr string) []Error {
+	if obj, ok := v.(map[string]interface{}); ok {
+		if key, values := s.discriminator(); key != "" {
+			got, present := obj[key]
+			for _, variant := range s.OneOf {
+				if variant.Const[key] == got {
+					return variant.validate(v, ptr)
+				}
+			}
+			if !present {
+				return []Error{{Pointer: ptr, Message: fmt.Sprintf("missing %q (one of %s)", key, strings.Join(values, ", "))}}
+			}
+			err := Error{Pointer: ptr + "/" + escape(key), Message: fmt.Sprintf("unknown %s %s (one of %s)", key, describe(got), strings.Join(values, ", "))}
+			if s, ok := got.(string); ok {
+				err.Suggestion = closest(s, values)
+			}
+			return []Error{err}
+		}
+	}
+
+	var best []Error
+	for i, variant := range s.OneOf {
+		errs := variant.validate(v, ptr)
+		if len(errs) == 0 {
+			return nil
+		}
+		if i == 0 || len(errs) < len(best) {
+			best = errs
+		}
+	}
+	return best
+}
+
+// discriminator returns the literal field every variant of a union sets,
+// and its possible values
+func (s *Schema) discriminator() (string, []string) {
+	first := s.OneOf[0]
+	for key := range first.Const {
+		var values []string
+		for _, variant := range s.OneOf {
+			value, ok := variant.Const[key].(string)
+			if !ok {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if values != nil {
+			sort.Strings(values)
+			return key, values
+		}
+	}
+	return "", nil
+}
+
+// suggest returns the property the user probably meant by key, relative to
+// the object it appeared in: the same name one level down, which catches
+// fields at the wrong level, or else a similarly spelled name
+func (s *Schema) suggest(key string) string {
+	names := s.propertyNames()
+	for _, name := range names {
+		child := s.property(name)
+		for child != nil && child.Items != nil {
+			child = child.Items
+		}
+		if child != nil && child.hasProperty(key) {
+			return name + "." + key
+		}
+	}
+	return closest(key, names)
+}
+
+// closest returns the candidate nearest to word, if it is close enough to
+// be a typo
+func closest(word string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(word), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	limit := len(word) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func mismatch(ptr string, want Type, got interface{}) Error {
+	return Error{Pointer: ptr, Message: fmt.Sprintf("expected %s, got %s", want, typeOf(got))}
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func describe(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
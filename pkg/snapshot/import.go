@@ -0,0 +1,343 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// Mapping records which target resource each exported resource became, so
+// an import can be re-run without creating duplicates
+type Mapping struct {
+	Target string            `json:"target"`
+	IDs    map[string]string `json:"ids"` // Source ID → target ID
+
+	path string
+}
+
+// LoadMapping reads the mapping at path, or starts an empty one for target
+// if the file doesn't exist yet
+func LoadMapping(path, target string) (*Mapping, error) {
+	m := &Mapping{Target: target, IDs: make(map[string]string), path: path}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping %s: %w", path, err)
+	}
+	if m.Target != target {
+		return nil, fmt.Errorf("mapping %s was written for account %q, not %q", path, m.Target, target)
+	}
+	if m.IDs == nil {
+		m.IDs = make(map[string]string)
+	}
+	m.path = path
+	return m, nil
+}
+
+// Save writes the mapping back to disk
+func (m *Mapping) Save() error {
+	return writeJSON(m.path, m)
+}
+
+// ImportResult counts what an import did
+type ImportResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+// AccountScopedError lists references to resources the import can't bring
+// into the target account, such as credentials and knowledge bases
+type AccountScopedError struct {
+	Refs map[string][]string // Exported file → paths holding such IDs
+}
+
+func (e *AccountScopedError) Error() string {
+	files := make([]string, 0, len(e.Refs))
+	for file := range e.Refs {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	var b strings.Builder
+	b.WriteString("the export refers to resources that only exist in the source account:")
+	for _, file := range files {
+		fmt.Fprintf(&b, "\n  %s: %s", file, strings.Join(e.Refs[file], ", "))
+	}
+	return b.String()
+}
+
+// Import recreates the resources exported to dir through remote. Embedded
+// IDs of exported resources (toolIds, squad members, phone number routing)
+// are rewritten to their target IDs. References to resources that aren't
+// created yet are left out until the second pass links them, so the target
+// never points at the source account. Other account-scoped IDs, such as
+// credentialIds, are an *AccountScopedError before anything is changed.
+// Resources already in the mapping are updated instead of created again, and
+// the mapping is saved after every create so an interrupted import can simply
+// be re-run.
+func Import(ctx context.Context, remote Remote, dir string, mapping *Mapping, out io.Writer) (*ImportResult, error) {
+	resources, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		exported[r.id] = true
+	}
+
+	if err := matchRouting(ctx, remote, resources, mapping); err != nil {
+		return nil, err
+	}
+	if err := checkAccountScoped(resources, mapping, exported); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	var pending []resource
+	for _, r := range resources {
+		body, unresolved := rewriteIDs(payload(r), mapping.IDs, exported)
+		targetID, known := mapping.IDs[r.id]
+
+		switch {
+		case r.kind.Routing != nil && !known:
+			fmt.Fprintf(out, "⚠️  Skipped %s %s: %s is not in the target account\n", r.kind.Resource.Name, r.id, describe(r))
+			result.Skipped++
+			continue
+
+		case known:
+			_, err := remote.UpdateRaw(ctx, r.kind.Resource, targetID, without(body, r.kind.Immutable))
+			if client.IsNotFound(err) && r.kind.Routing == nil {
+				// Deleted from the target since the last run; recreate it
+				delete(mapping.IDs, r.id)
+				if targetID, err = create(ctx, remote, r, body, mapping); err != nil {
+					return result, err
+				}
+				result.Created++
+				fmt.Fprintf(out, "✅ Recreated %s %s → %s\n", r.kind.Resource.Name, describe(r), targetID)
+				break
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to update %s %s: %w", r.kind.Resource.Name, describe(r), err)
+			}
+			result.Updated++
+			fmt.Fprintf(out, "✅ Updated %s %s (%s)\n", r.kind.Resource.Name, describe(r), targetID)
+
+		default:
+			if targetID, err = create(ctx, remote, r, body, mapping); err != nil {
+				return result, err
+			}
+			result.Created++
+			fmt.Fprintf(out, "✅ Created %s %s → %s\n", r.kind.Resource.Name, describe(r), targetID)
+		}
+
+		if unresolved {
+			pending = append(pending, r)
+		}
+	}
+
+	// Second pass for references to resources that were created later
+	for _, r := range pending {
+		body, _ := rewriteIDs(payload(r), mapping.IDs, exported)
+		targetID := mapping.IDs[r.id]
+		if _, err := remote.UpdateRaw(ctx, r.kind.Resource, targetID, without(body, r.kind.Immutable)); err != nil {
+			return result, fmt.Errorf("failed to update references in %s %s: %w", r.kind.Resource.Name, describe(r), err)
+		}
+		fmt.Fprintf(out, "🔗 Linked references in %s %s\n", r.kind.Resource.Name, describe(r))
+	}
+
+	return result, nil
+}
+
+func create(ctx context.Context, remote Remote, r resource, body map[string]interface{}, mapping *Mapping) (string, error) {
+	created, err := remote.CreateRaw(ctx, r.kind.Resource, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s %s: %w", r.kind.Resource.Name, describe(r), err)
+	}
+	targetID, _ := created["id"].(string)
+	if targetID == "" {
+		return "", fmt.Errorf("creating %s %s returned no ID", r.kind.Resource.Name, describe(r))
+	}
+	mapping.IDs[r.id] = targetID
+	if err := mapping.Save(); err != nil {
+		return "", err
+	}
+	return targetID, nil
+}
+
+// matchRouting maps exported routing resources, such as phone numbers, to
+// the target resources with the same number
+func matchRouting(ctx context.Context, remote Remote, resources []resource, mapping *Mapping) error {
+	targets := make(map[string][]map[string]interface{})
+	for _, r := range resources {
+		if r.kind.Routing == nil {
+			continue
+		}
+		items, ok := targets[r.kind.Dir]
+		if !ok {
+			var err error
+			if items, err = remote.ListAllRaw(ctx, r.kind.Resource); err != nil {
+				return fmt.Errorf("failed to list target %ss: %w", r.kind.Resource.Name, err)
+			}
+			targets[r.kind.Dir] = items
+		}
+
+		delete(mapping.IDs, r.id)
+		for _, field := range r.kind.MatchFields {
+			want := client.LookupString(r.data, field)
+			if want == "" {
+				continue
+			}
+			for _, item := range items {
+				if client.LookupString(item, field) == want {
+					mapping.IDs[r.id], _ = item["id"].(string)
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// checkAccountScoped fails with an *AccountScopedError if a resource that
+// will be imported holds account-scoped IDs the import can't map: IDs of
+// resources that weren't exported, or of phone numbers missing from the
+// target
+func checkAccountScoped(resources []resource, mapping *Mapping, exported map[string]bool) error {
+	skipped := make(map[string]bool)
+	for _, r := range resources {
+		if r.kind.Routing != nil && mapping.IDs[r.id] == "" {
+			skipped[r.id] = true
+		}
+	}
+	known := func(id string) bool { return exported[id] && !skipped[id] }
+
+	refs := make(map[string][]string)
+	for _, r := range resources {
+		if skipped[r.id] {
+			continue
+		}
+		if paths := AccountScopedRefs(payload(r), known); len(paths) > 0 {
+			refs[filepath.Join(r.kind.Dir, r.id+".json")] = paths
+		}
+	}
+	if len(refs) > 0 {
+		return &AccountScopedError{Refs: refs}
+	}
+	return nil
+}
+
+// payload strips the fields the API won't accept from an exported resource
+func payload(r resource) map[string]interface{} {
+	if r.kind.Routing != nil {
+		body := make(map[string]interface{}, len(r.kind.Routing))
+		for _, field := range r.kind.Routing {
+			if v, ok := r.data[field]; ok {
+				body[field] = v
+			}
+		}
+		return body
+	}
+
+	return r.kind.Resource.Editable(r.data)
+}
+
+func without(body map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return body
+	}
+	out := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		out[k] = v
+	}
+	for _, field := range fields {
+		delete(out, field)
+	}
+	return out
+}
+
+// rewriteIDs replaces every string equal to an exported ID with its target
+// ID. Exported IDs with no target yet are left out: dropped from arrays and
+// removed as object values. unresolved reports whether any were.
+func rewriteIDs(body map[string]interface{}, ids map[string]string, exported map[string]bool) (map[string]interface{}, bool) {
+	unresolved := false
+	// rewrite returns false when v is a reference that must be left out
+	var rewrite func(v interface{}) (interface{}, bool)
+	rewrite = func(v interface{}) (interface{}, bool) {
+		switch value := v.(type) {
+		case string:
+			if !exported[value] {
+				return value, true
+			}
+			if target, ok := ids[value]; ok {
+				return target, true
+			}
+			unresolved = true
+			return nil, false
+		case map[string]interface{}:
+			out := make(map[string]interface{}, len(value))
+			for k, item := range value {
+				if rewritten, keep := rewrite(item); keep {
+					out[k] = rewritten
+				}
+			}
+			return out, true
+		case []interface{}:
+			out := make([]interface{}, 0, len(value))
+			for _, item := range value {
+				if rewritten, keep := rewrite(item); keep {
+					out = append(out, rewritten)
+				}
+			}
+			return out, true
+		default:
+			return value, true
+		}
+	}
+	out, _ := rewrite(body)
+	return out.(map[string]interface{}), unresolved
+}
+
+// describe names a resource for progress messages
+func describe(r resource) string {
+	for _, field := range r.kind.MatchFields {
+		if v := client.LookupString(r.data, field); v != "" {
+			return v
+		}
+	}
+	if name := r.kind.Resource.NameOf(r.data); name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return r.id
+}
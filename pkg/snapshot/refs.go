@@ -0,0 +1,90 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// accountScopedFields hold IDs of resources that only exist in the account
+// a resource belongs to, such as credentials and knowledge bases. Tool IDs
+// are handled separately, since tools can be matched or copied.
+var accountScopedFields = map[string]bool{
+	"credentialId":    true,
+	"credentialIds":   true,
+	"knowledgeBaseId": true,
+	"assistantId":     true,
+	"squadId":         true,
+	"workflowId":      true,
+	"phoneNumberId":   true,
+}
+
+// AccountScopedRefs lists the paths in doc, in --set syntax, that hold IDs
+// of resources in doc's own account. IDs for which known returns true can be
+// carried over and are not listed; a nil known lists every such path.
+func AccountScopedRefs(doc map[string]interface{}, known func(id string) bool) []string {
+	var refs []string
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for k, item := range value {
+				p := k
+				if path != "" {
+					p = path + "." + k
+				}
+				if accountScopedFields[k] {
+					if hasUnknownID(item, known) {
+						refs = append(refs, p)
+					}
+					continue
+				}
+				walk(p, item)
+			}
+		case []interface{}:
+			for i, item := range value {
+				walk(fmt.Sprintf("%s[%d]", path, i), item)
+			}
+		}
+	}
+	walk("", doc)
+	sort.Strings(refs)
+	return refs
+}
+
+// hasUnknownID reports whether v, an ID or a list of IDs, holds one that
+// known doesn't accept
+func hasUnknownID(v interface{}, known func(id string) bool) bool {
+	switch value := v.(type) {
+	case string:
+		return value != "" && (known == nil || !known(value))
+	case []interface{}:
+		for _, item := range value {
+			if hasUnknownID(item, known) {
+				return true
+			}
+		}
+		return false
+	case nil:
+		return false
+	default:
+		return true
+	}
+}
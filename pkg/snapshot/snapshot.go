@@ -0,0 +1,177 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// metadataFile describes an export and lives at the root of its directory
+const metadataFile = "export.json"
+
+// Kind is one resource type included in a snapshot
+type Kind struct {
+	Dir      string // Subdirectory holding one JSON file per resource
+	Resource client.ResourceKind
+
+	// Immutable fields can be set on create but are dropped before updating
+	Immutable []string
+
+	// Routing kinds can't be created by import. Only the Routing fields are
+	// copied onto an existing target resource matched on MatchFields.
+	Routing     []string
+	MatchFields []string
+}
+
+// Kinds lists every exported kind in the order import recreates them, so
+// most references point at resources that already exist
+var Kinds = []Kind{
+	{Dir: "tools", Resource: client.Tools, Immutable: []string{"type"}},
+	{Dir: "assistants", Resource: client.Assistants},
+	{Dir: "squads", Resource: client.Squads},
+	{Dir: "workflows", Resource: client.Workflows},
+	{
+		Dir:         "phone-numbers",
+		Resource:    client.PhoneNumbers,
+		Routing:     []string{"name", "assistantId", "squadId", "workflowId", "server", "fallbackDestination"},
+		MatchFields: []string{"number", "sipUri"},
+	},
+}
+
+// Remote is the API surface export and import need, satisfied by
+// *client.VapiClient
+type Remote interface {
+	ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error)
+	CreateRaw(ctx context.Context, kind client.ResourceKind, body map[string]interface{}) (map[string]interface{}, error)
+	UpdateRaw(ctx context.Context, kind client.ResourceKind, id string, body map[string]interface{}) (map[string]interface{}, error)
+}
+
+// Metadata describes where and when a snapshot was taken
+type Metadata struct {
+	ExportedAt  time.Time      `json:"exportedAt"`
+	Account     string         `json:"account,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Counts      map[string]int `json:"counts"`
+}
+
+// Export writes every resource of every kind to dir, one JSON file per
+// resource named after its ID. Files left from an earlier export of the same
+// kind are removed so deleted resources don't linger.
+func Export(ctx context.Context, remote Remote, dir string, meta Metadata, out io.Writer) (*Metadata, error) {
+	meta.ExportedAt = time.Now().UTC()
+	meta.Counts = make(map[string]int)
+
+	for _, kind := range Kinds {
+		items, err := remote.ListAllRaw(ctx, kind.Resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", kind.Resource.Name, err)
+		}
+
+		kindDir := filepath.Join(dir, kind.Dir)
+		if err := os.MkdirAll(kindDir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", kindDir, err)
+		}
+		stale, err := filepath.Glob(filepath.Join(kindDir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range stale {
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+
+		for _, item := range items {
+			id, _ := item["id"].(string)
+			if id == "" {
+				continue
+			}
+			if err := writeJSON(filepath.Join(kindDir, id+".json"), item); err != nil {
+				return nil, err
+			}
+		}
+		meta.Counts[kind.Dir] = len(items)
+		fmt.Fprintf(out, "📦 Exported %d %s\n", len(items), kind.Dir)
+	}
+
+	if err := writeJSON(filepath.Join(dir, metadataFile), meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// resource is one exported resource read back from disk
+type resource struct {
+	kind Kind
+	id   string
+	data map[string]interface{}
+}
+
+// load reads every exported resource in dir, in import order
+func load(dir string) ([]resource, error) {
+	if _, err := os.Stat(filepath.Join(dir, metadataFile)); err != nil {
+		return nil, fmt.Errorf("%s is not an export directory (missing %s)", dir, metadataFile)
+	}
+
+	var resources []resource
+	for _, kind := range Kinds {
+		paths, err := filepath.Glob(filepath.Join(dir, kind.Dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			data, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			var item map[string]interface{}
+			if err := json.Unmarshal(data, &item); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			id, _ := item["id"].(string)
+			if id == "" {
+				id = strings.TrimSuffix(filepath.Base(path), ".json")
+			}
+			resources = append(resources, resource{kind: kind, id: id, data: item})
+		}
+	}
+	return resources, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
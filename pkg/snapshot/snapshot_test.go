@@ -0,0 +1,274 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// fakeRemote is an in-memory account keyed by resource path
+type fakeRemote struct {
+	items   map[string][]map[string]interface{}
+	prefix  string
+	next    int
+	creates int
+
+	failUpdates bool
+}
+
+func (f *fakeRemote) ListAllRaw(ctx context.Context, kind client.ResourceKind) ([]map[string]interface{}, error) {
+	return f.items[kind.Path], nil
+}
+
+func (f *fakeRemote) CreateRaw(ctx context.Context, kind client.ResourceKind, body map[string]interface{}) (map[string]interface{}, error) {
+	f.next++
+	f.creates++
+	item := map[string]interface{}{"id": fmt.Sprintf("%s-%d", f.prefix, f.next)}
+	for k, v := range body {
+		item[k] = v
+	}
+	f.items[kind.Path] = append(f.items[kind.Path], item)
+	return item, nil
+}
+
+func (f *fakeRemote) UpdateRaw(ctx context.Context, kind client.ResourceKind, id string, body map[string]interface{}) (map[string]interface{}, error) {
+	if f.failUpdates {
+		return nil, &client.APIError{StatusCode: 500, Body: "unavailable"}
+	}
+	for _, item := range f.items[kind.Path] {
+		if item["id"] == id {
+			for k, v := range body {
+				item[k] = v
+			}
+			return item, nil
+		}
+	}
+	return nil, &client.APIError{StatusCode: 404, Body: "not found"}
+}
+
+func (f *fakeRemote) get(path, id string) map[string]interface{} {
+	for _, item := range f.items[path] {
+		if item["id"] == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestExportImport(t *testing.T) {
+	source := &fakeRemote{items: map[string][]map[string]interface{}{
+		"/tool": {
+			{"id": "t1", "type": "function", "orgId": "src", "function": map[string]interface{}{"name": "lookup"}},
+		},
+		"/assistant": {
+			{"id": "a1", "name": "support", "orgId": "src", "isServerUrlSecretSet": false,
+				"model": map[string]interface{}{"toolIds": []interface{}{"t1"}}},
+		},
+		"/squad": {
+			{"id": "s1", "name": "team", "members": []interface{}{map[string]interface{}{"assistantId": "a1"}}},
+		},
+		"/phone-number": {
+			{"id": "p1", "number": "+15550001", "assistantId": "a1", "provider": "twilio"},
+			{"id": "p2", "number": "+15550002", "assistantId": "a1"},
+		},
+	}}
+	dir := t.TempDir()
+
+	meta, err := Export(context.Background(), source, dir, Metadata{Account: "dev"}, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, meta.Counts["assistants"])
+	assert.FileExists(t, filepath.Join(dir, "tools", "t1.json"))
+	assert.FileExists(t, filepath.Join(dir, metadataFile))
+
+	target := &fakeRemote{prefix: "new", items: map[string][]map[string]interface{}{
+		"/phone-number": {{"id": "tp1", "number": "+15550001"}},
+	}}
+	mappingPath := filepath.Join(dir, "import-prod.json")
+	mapping, err := LoadMapping(mappingPath, "prod")
+	require.NoError(t, err)
+
+	result, err := Import(context.Background(), target, dir, mapping, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, &ImportResult{Created: 3, Updated: 1, Skipped: 1}, result)
+
+	assistant := target.get("/assistant", mapping.IDs["a1"])
+	require.NotNil(t, assistant)
+	assert.Equal(t, []interface{}{mapping.IDs["t1"]}, assistant["model"].(map[string]interface{})["toolIds"])
+	assert.NotContains(t, assistant, "orgId")
+	assert.NotContains(t, assistant, "isServerUrlSecretSet")
+
+	squad := target.get("/squad", mapping.IDs["s1"])
+	assert.Equal(t, mapping.IDs["a1"], squad["members"].([]interface{})[0].(map[string]interface{})["assistantId"])
+
+	phone := target.get("/phone-number", "tp1")
+	assert.Equal(t, mapping.IDs["a1"], phone["assistantId"])
+	assert.NotContains(t, phone, "provider")
+
+	// Re-running updates the same resources instead of duplicating them
+	mapping, err = LoadMapping(mappingPath, "prod")
+	require.NoError(t, err)
+	result, err = Import(context.Background(), target, dir, mapping, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, &ImportResult{Updated: 4, Skipped: 1}, result)
+	assert.Equal(t, 3, target.creates)
+
+	_, err = LoadMapping(mappingPath, "staging")
+	assert.ErrorContains(t, err, `written for account "prod"`)
+}
+
+func TestImportSecondPass(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeJSON(filepath.Join(dir, metadataFile), Metadata{}))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tools"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assistants"), 0o750))
+
+	// A tool that transfers to an assistant is imported before the assistant
+	require.NoError(t, writeJSON(filepath.Join(dir, "tools", "t1.json"), map[string]interface{}{
+		"id": "t1", "type": "transferCall", "destinations": []interface{}{map[string]interface{}{"assistantId": "a1"}},
+	}))
+	require.NoError(t, writeJSON(filepath.Join(dir, "assistants", "a1.json"), map[string]interface{}{"id": "a1", "name": "support"}))
+
+	target := &fakeRemote{prefix: "new", items: map[string][]map[string]interface{}{}}
+	mapping, err := LoadMapping(filepath.Join(dir, "import.json"), "prod")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	_, err = Import(context.Background(), target, dir, mapping, &out)
+	require.NoError(t, err)
+
+	tool := target.get("/tool", mapping.IDs["t1"])
+	assert.Equal(t, mapping.IDs["a1"], tool["destinations"].([]interface{})[0].(map[string]interface{})["assistantId"])
+	assert.Equal(t, "transferCall", tool["type"])
+	assert.Contains(t, out.String(), "Linked references")
+}
+
+func TestImportLeavesOutUnresolvedReferences(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeJSON(filepath.Join(dir, metadataFile), Metadata{}))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tools"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assistants"), 0o750))
+	require.NoError(t, writeJSON(filepath.Join(dir, "tools", "t1.json"), map[string]interface{}{
+		"id": "t1", "type": "transferCall", "destinations": []interface{}{map[string]interface{}{"type": "assistant", "assistantId": "a1"}},
+	}))
+	require.NoError(t, writeJSON(filepath.Join(dir, "assistants", "a1.json"), map[string]interface{}{"id": "a1", "name": "support"}))
+
+	// The second pass fails, so the tool keeps what it was created with
+	target := &fakeRemote{prefix: "new", items: map[string][]map[string]interface{}{}, failUpdates: true}
+	mapping, err := LoadMapping(filepath.Join(dir, "import.json"), "prod")
+	require.NoError(t, err)
+
+	_, err = Import(context.Background(), target, dir, mapping, &bytes.Buffer{})
+	require.ErrorContains(t, err, "failed to update references")
+
+	tool := target.get("/tool", mapping.IDs["t1"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "assistant"}}, tool["destinations"])
+}
+
+func TestImportRefusesAccountScopedIDs(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, writeJSON(filepath.Join(dir, metadataFile), Metadata{}))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assistants"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "phone-numbers"), 0o750))
+	require.NoError(t, writeJSON(filepath.Join(dir, "assistants", "a1.json"), map[string]interface{}{
+		"id": "a1", "name": "support", "credentialIds": []interface{}{"cred-1"},
+		"model":  map[string]interface{}{"knowledgeBaseId": "kb-1"},
+		"server": map[string]interface{}{"url": "https://example.com"},
+	}))
+	require.NoError(t, writeJSON(filepath.Join(dir, "assistants", "a2.json"), map[string]interface{}{
+		"id": "a2", "name": "sales", "squadId": "s-not-exported",
+	}))
+	// Numbers missing from the target are skipped, so their routing is not checked
+	require.NoError(t, writeJSON(filepath.Join(dir, "phone-numbers", "p1.json"), map[string]interface{}{
+		"id": "p1", "number": "+15550001", "server": map[string]interface{}{"credentialId": "cred-2"},
+	}))
+
+	target := &fakeRemote{prefix: "new", items: map[string][]map[string]interface{}{}}
+	mapping, err := LoadMapping(filepath.Join(dir, "import.json"), "prod")
+	require.NoError(t, err)
+
+	_, err = Import(context.Background(), target, dir, mapping, &bytes.Buffer{})
+	var scoped *AccountScopedError
+	require.ErrorAs(t, err, &scoped)
+	assert.Equal(t, map[string][]string{
+		filepath.Join("assistants", "a1.json"): {"credentialIds", "model.knowledgeBaseId"},
+		filepath.Join("assistants", "a2.json"): {"squadId"},
+	}, scoped.Refs)
+	assert.Equal(t, 0, target.creates)
+}
+
+func TestMapTools(t *testing.T) {
+	source := &fakeRemote{items: map[string][]map[string]interface{}{
+		"/tool": {
+			{"id": "t1", "type": "function", "orgId": "src", "function": map[string]interface{}{"name": "lookup"}},
+			{"id": "t2", "type": "endCall", "orgId": "src"},
+		},
+	}}
+	target := &fakeRemote{prefix: "new", items: map[string][]map[string]interface{}{
+		"/tool": {{"id": "tt1", "type": "function", "function": map[string]interface{}{"name": "lookup"}}},
+	}}
+
+	_, err := MapTools(context.Background(), source, target, []string{"t1", "t2"}, false, &bytes.Buffer{})
+	var missing *MissingToolsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"t2"}, missing.IDs)
+	assert.Equal(t, 0, target.creates)
+
+	mapping, err := MapTools(context.Background(), source, target, []string{"t1", "t2", "t1"}, true, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, "tt1", mapping["t1"])
+	assert.Equal(t, "new-1", mapping["t2"])
+	assert.Equal(t, "endCall", target.get("/tool", "new-1")["type"])
+	assert.NotContains(t, target.get("/tool", "new-1"), "orgId")
+
+	// A copy that would carry the source account's credentials is refused
+	source.items["/tool"] = append(source.items["/tool"], map[string]interface{}{
+		"id": "t3", "type": "function", "function": map[string]interface{}{"name": "charge"},
+		"server": map[string]interface{}{"url": "https://example.com", "credentialId": "cred-1"},
+	})
+	creates := target.creates
+	_, err = MapTools(context.Background(), source, target, []string{"t3"}, true, &bytes.Buffer{})
+	assert.ErrorContains(t, err, `tool "charge" (t3) refers to resources that only exist in the source account: server.credentialId`)
+	assert.Equal(t, creates, target.creates)
+
+	// Within one account every tool already exists
+	mapping, err = MapTools(context.Background(), source, source, []string{"t2"}, false, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"t2": "t2"}, mapping)
+}
+
+func TestAccountScopedRefs(t *testing.T) {
+	assistant := map[string]interface{}{
+		"name":          "support",
+		"credentialIds": []interface{}{"cred-1"},
+		"model": map[string]interface{}{
+			"toolIds":         []interface{}{"t1"},
+			"knowledgeBaseId": "kb-1",
+			"tools": []interface{}{map[string]interface{}{
+				"type":         "transferCall",
+				"destinations": []interface{}{map[string]interface{}{"type": "assistant", "assistantId": "a2"}},
+			}},
+		},
+		"server": map[string]interface{}{"url": "https://example.com", "credentialId": "cred-2"},
+		"voice":  map[string]interface{}{"credentialIds": []interface{}{}},
+	}
+	assert.Equal(t, []string{
+		"credentialIds",
+		"model.knowledgeBaseId",
+		"model.tools[0].destinations[0].assistantId",
+		"server.credentialId",
+	}, AccountScopedRefs(assistant, nil))
+
+	assert.Empty(t, AccountScopedRefs(map[string]interface{}{"model": map[string]interface{}{"toolIds": []interface{}{"t1"}}}, nil))
+
+	// IDs the caller can carry over, such as exported assistants, are fine
+	exported := func(id string) bool { return id == "a2" }
+	assert.Equal(t, []string{"credentialIds", "model.knowledgeBaseId", "server.credentialId"}, AccountScopedRefs(assistant, exported))
+}
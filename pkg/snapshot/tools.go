@@ -0,0 +1,157 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/VapiAI/cli/pkg/client"
+)
+
+// MissingToolsError lists tools that exist in the source account but not in
+// the target
+type MissingToolsError struct {
+	IDs []string
+}
+
+func (e *MissingToolsError) Error() string {
+	return fmt.Sprintf("%d tool(s) missing from the target account: %s", len(e.IDs), strings.Join(e.IDs, ", "))
+}
+
+// MapTools finds a target tool for each source tool ID. A tool with the same
+// ID, or else the same name, in target is reused. Tools with neither are
+// created from their source configuration when create is set and reported as
+// a *MissingToolsError otherwise. A tool to be created that holds IDs only
+// valid in the source account, such as server.credentialId, is refused
+// before any tool is created.
+func MapTools(ctx context.Context, source, target Remote, ids []string, create bool, out io.Writer) (map[string]string, error) {
+	mapping := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return mapping, nil
+	}
+
+	targetTools, err := target.ListAllRaw(ctx, client.Tools)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target tools: %w", err)
+	}
+	targetByID := make(map[string]bool, len(targetTools))
+	targetByName := make(map[string]string, len(targetTools))
+	for _, tool := range targetTools {
+		id, _ := tool["id"].(string)
+		targetByID[id] = true
+		if name := client.Tools.NameOf(tool); name != "" {
+			targetByName[name] = id
+		}
+	}
+
+	var sourceByID map[string]map[string]interface{}
+	var missing, copies []string
+	copyOf := make(map[string]string) // Source ID → source ID of the copy it will use
+	copyByName := make(map[string]string)
+	for _, id := range ids {
+		if _, done := mapping[id]; done {
+			continue
+		}
+		if _, planned := copyOf[id]; planned {
+			continue
+		}
+		if targetByID[id] {
+			mapping[id] = id
+			continue
+		}
+
+		if sourceByID == nil {
+			sourceTools, err := source.ListAllRaw(ctx, client.Tools)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list source tools: %w", err)
+			}
+			sourceByID = make(map[string]map[string]interface{}, len(sourceTools))
+			for _, tool := range sourceTools {
+				toolID, _ := tool["id"].(string)
+				sourceByID[toolID] = tool
+			}
+		}
+		tool, ok := sourceByID[id]
+		if !ok {
+			return nil, fmt.Errorf("tool %s not found in the source account", id)
+		}
+
+		name := client.Tools.NameOf(tool)
+		if targetID, ok := targetByName[name]; ok && name != "" {
+			mapping[id] = targetID
+			fmt.Fprintf(out, "🔗 Using existing tool %q (%s)\n", name, targetID)
+			continue
+		}
+		if !create {
+			missing = append(missing, id)
+			continue
+		}
+		if first, ok := copyByName[name]; ok && name != "" {
+			copyOf[id] = first
+			continue
+		}
+		copyOf[id] = id
+		copyByName[name] = id
+		copies = append(copies, id)
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return mapping, &MissingToolsError{IDs: missing}
+	}
+
+	// Check every copy before creating any, so a refused tool doesn't leave
+	// the others behind in the target
+	for _, id := range copies {
+		if refs := AccountScopedRefs(client.Tools.Editable(sourceByID[id]), nil); len(refs) > 0 {
+			return nil, fmt.Errorf("tool %s refers to resources that only exist in the source account: %s\nCreate the tool in the target account first, or remove those fields from it", describeTool(sourceByID[id], id), strings.Join(refs, ", "))
+		}
+	}
+
+	for _, id := range copies {
+		tool := sourceByID[id]
+		name := client.Tools.NameOf(tool)
+		created, err := target.CreateRaw(ctx, client.Tools, client.Tools.Editable(tool))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tool %s: %w", id, err)
+		}
+		targetID, _ := created["id"].(string)
+		if targetID == "" {
+			return nil, fmt.Errorf("creating tool %s returned no ID", id)
+		}
+		mapping[id] = targetID
+		fmt.Fprintf(out, "✅ Created tool %q → %s\n", name, targetID)
+	}
+	for id, first := range copyOf {
+		mapping[id] = mapping[first]
+	}
+	return mapping, nil
+}
+
+// describeTool names a tool for messages
+func describeTool(tool map[string]interface{}, id string) string {
+	if name := client.Tools.NameOf(tool); name != "" {
+		return fmt.Sprintf("%q (%s)", name, id)
+	}
+	return id
+}
@@ -0,0 +1,163 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package transcript
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is a way of writing a transcript
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatMarkdown Format = "md"
+	FormatSRT      Format = "srt"
+	FormatVTT      Format = "vtt"
+)
+
+// Formats lists the formats Write supports
+var Formats = []Format{FormatText, FormatMarkdown, FormatSRT, FormatVTT}
+
+// minCue is how long a subtitle stays up when a turn has no end time
+const minCue = 2 * time.Second
+
+// Write renders turns to w. title, if set, heads the Markdown output.
+// Subtitles only carry speech, so tool calls and results are left out of
+// SRT and WebVTT.
+func Write(w io.Writer, turns []Turn, format Format, title string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, turns)
+	case FormatMarkdown:
+		return writeMarkdown(w, turns, title)
+	case FormatSRT, FormatVTT:
+		return writeSubtitles(w, speech(turns), format)
+	default:
+		return fmt.Errorf("unknown transcript format %q", format)
+	}
+}
+
+func writeText(w io.Writer, turns []Turn) error {
+	for _, t := range turns {
+		if _, err := fmt.Fprintln(w, t.Line()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdown(w io.Writer, turns []Turn, title string) error {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", title)
+	}
+	for _, t := range turns {
+		offset := FormatOffset(t.Start)
+		switch t.Kind {
+		case KindToolCall:
+			fmt.Fprintf(&b, "- `%s` 🔧 %s\n", offset, codeSpan(t.Text))
+		case KindToolResult:
+			fmt.Fprintf(&b, "- `%s` ↩️ %s: %s\n", offset, markdownEscaper.Replace(t.Tool), codeSpan(t.Text))
+		default:
+			fmt.Fprintf(&b, "- `%s` **%s:** %s\n", offset, markdownEscaper.Replace(t.Speaker), markdownEscaper.Replace(t.Text))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscaper keeps backticks in plain Markdown text from opening a code
+// span
+var markdownEscaper = strings.NewReplacer("`", "\\`")
+
+// codeSpan wraps s in a Markdown code span whose fence is longer than any
+// run of backticks inside it, padding with spaces when s starts or ends
+// with one
+func codeSpan(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// vttEscaper escapes the characters WebVTT treats as markup in cue text, so
+// a "<", "&" or "-->" in a turn shows up as written
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// writeSubtitles writes SRT or WebVTT cues. Turns without an end time are
+// shown until the next turn starts, or for minCue.
+func writeSubtitles(w io.Writer, turns []Turn, format Format) error {
+	var b strings.Builder
+	if format == FormatVTT {
+		b.WriteString("WEBVTT\n\n")
+	}
+	for i, t := range turns {
+		end := t.End
+		if end <= t.Start {
+			end = t.Start + minCue
+			if i+1 < len(turns) && turns[i+1].Start > t.Start && turns[i+1].Start < end {
+				end = turns[i+1].Start
+			}
+		}
+		if format == FormatSRT {
+			fmt.Fprintf(&b, "%d\n%s --> %s\n%s: %s\n\n", i+1, cueTime(t.Start, ","), cueTime(end, ","), t.Speaker, t.Text)
+		} else {
+			fmt.Fprintf(&b, "%s --> %s\n<v %s>%s\n\n", cueTime(t.Start, "."), cueTime(end, "."), vttEscaper.Replace(t.Speaker), vttEscaper.Replace(t.Text))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// cueTime formats an offset as hh:mm:ss followed by sep and milliseconds,
+// as SRT (",") and WebVTT (".") expect
+func cueTime(d time.Duration, sep string) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// speech returns the turns spoken by the customer or the assistant
+func speech(turns []Turn) []Turn {
+	var out []Turn
+	for _, t := range turns {
+		if t.Kind == KindCustomer || t.Kind == KindAssistant {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package transcript
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, turns []Turn, format Format, title string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, turns, format, title))
+	return buf.String()
+}
+
+func TestWrite(t *testing.T) {
+	turns := parseFixture(t)[1:] // Without the system prompt
+
+	assert.Equal(t, `[00:00] Assistant: Hi, how can I help?
+[00:03] Customer: What's my   balance?
+[00:05] → getBalance({"account":"42"})
+[00:05] ← getBalance: {"balance":10}
+[01:06] Assistant: You have ten dollars.
+`, render(t, turns, FormatText, ""))
+
+	assert.Equal(t, "# Call c1\n\n"+
+		"- `00:00` **Assistant:** Hi, how can I help?\n"+
+		"- `00:03` **Customer:** What's my   balance?\n"+
+		"- `00:05` 🔧 `getBalance({\"account\":\"42\"})`\n"+
+		"- `00:05` ↩️ getBalance: `{\"balance\":10}`\n"+
+		"- `01:06` **Assistant:** You have ten dollars.\n",
+		render(t, turns, FormatMarkdown, "Call c1"))
+
+	assert.Equal(t, `1
+00:00:00,500 --> 00:00:02,000
+Assistant: Hi, how can I help?
+
+2
+00:00:03,000 --> 00:00:04,500
+Customer: What's my   balance?
+
+3
+00:01:06,000 --> 00:01:08,000
+Assistant: You have ten dollars.
+
+`, render(t, turns, FormatSRT, ""))
+
+	assert.Equal(t, `WEBVTT
+
+00:00:00.500 --> 00:00:02.000
+<v Assistant>Hi, how can I help?
+
+00:00:03.000 --> 00:00:04.500
+<v Customer>What's my   balance?
+
+00:01:06.000 --> 00:01:08.000
+<v Assistant>You have ten dollars.
+
+`, render(t, turns, FormatVTT, ""))
+
+	assert.Error(t, Write(&bytes.Buffer{}, turns, Format("pdf"), ""))
+}
+
+func TestSubtitleCueEndsAtNextTurn(t *testing.T) {
+	turns := []Turn{
+		{Kind: KindAssistant, Speaker: "Assistant", Text: "Hi", Start: 0},
+		{Kind: KindCustomer, Speaker: "Customer", Text: "Hello", Start: 1500 * time.Millisecond},
+	}
+	assert.Contains(t, render(t, turns, FormatVTT, ""), "00:00:00.000 --> 00:00:01.500\n<v Assistant>Hi")
+}
+
+func TestWriteEscapesMarkup(t *testing.T) {
+	turns := []Turn{
+		{Kind: KindCustomer, Speaker: "Customer", Text: "Is 1 < 2 & 3 --> 4? Run `ls`"},
+		{Kind: KindToolCall, Speaker: "Tool call", Text: "shell({\"cmd\":\"echo `date`\"})", Tool: "shell"},
+		{Kind: KindToolResult, Speaker: "Tool result", Text: "`ok`", Tool: "shell"},
+	}
+
+	assert.Contains(t, render(t, turns, FormatVTT, ""), "<v Customer>Is 1 &lt; 2 &amp; 3 --&gt; 4? Run `ls`\n")
+
+	assert.Equal(t, "- `00:00` **Customer:** Is 1 < 2 & 3 --> 4? Run \\`ls\\`\n"+
+		"- `00:00` 🔧 ``shell({\"cmd\":\"echo `date`\"})``\n"+
+		"- `00:00` ↩️ shell: `` `ok` ``\n",
+		render(t, turns, FormatMarkdown, ""))
+}
+
+func TestCodeSpan(t *testing.T) {
+	assert.Equal(t, "`plain`", codeSpan("plain"))
+	assert.Equal(t, "```a``b```", codeSpan("a``b"))
+	assert.Equal(t, "`` `x ``", codeSpan("`x"))
+}
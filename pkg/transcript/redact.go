@@ -0,0 +1,84 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package transcript
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// 13 to 19 digits, optionally grouped with spaces or dashes. A leading
+	// "+" marks a long international phone number instead.
+	cardPattern = regexp.MustCompile(`(?:\+|\b)\d(?:[ -]?\d){12,18}\b`)
+	// An optional country code, then an area code and two groups of digits,
+	// e.g. +1 (415) 555-0123 or 020 7183 8750
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?(?:\(\d{2,4}\)|\d{2,4})|\(\d{2,4}\)|\b\d{2,4})[\s.-]?\d{3,4}[\s.-]?\d{3,4}\b`)
+)
+
+// Redact returns a copy of turns with email addresses, card numbers and
+// phone numbers replaced by [email], [card] and [phone]. Only digit runs
+// that pass the Luhn check are treated as card numbers; other runs of card
+// length become [number] so no part of a misread card number is left.
+func Redact(turns []Turn) []Turn {
+	out := make([]Turn, len(turns))
+	for i, t := range turns {
+		t.Text = RedactText(t.Text)
+		out[i] = t
+	}
+	return out
+}
+
+// RedactText replaces personal details in s, as Redact does for turns
+func RedactText(s string) string {
+	s = emailPattern.ReplaceAllString(s, "[email]")
+	s = cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		switch {
+		case strings.HasPrefix(match, "+"):
+			return "[phone]"
+		case luhn(match):
+			return "[card]"
+		default:
+			return "[number]"
+		}
+	})
+	return phonePattern.ReplaceAllString(s, "[phone]")
+}
+
+// luhn reports whether the digits in s pass the Luhn checksum used by
+// payment card numbers
+func luhn(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2025 Vapi, Inc.
+
+Licensed under the MIT License (the "License");
+you may not use this file except in compliance with the License.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+
+Authors:
+
+	Dan Goosewin <dan@vapi.ai>
+*/
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind is who or what produced a turn
+type Kind string
+
+const (
+	KindCustomer   Kind = "customer"
+	KindAssistant  Kind = "assistant"
+	KindSystem     Kind = "system"
+	KindToolCall   Kind = "tool-call"
+	KindToolResult Kind = "tool-result"
+)
+
+// Turn is one entry of a call transcript
+type Turn struct {
+	Kind    Kind
+	Speaker string // Label shown before the text, e.g. "Customer"
+	Text    string
+	Tool    string        // Tool name, for tool calls and results
+	Start   time.Duration // Offset from the start of the call
+	End     time.Duration
+}
+
+// MarshalJSON writes offsets as seconds so the output is usable outside Go
+func (t Turn) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind    Kind    `json:"kind"`
+		Speaker string  `json:"speaker"`
+		Text    string  `json:"text"`
+		Tool    string  `json:"tool,omitempty"`
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+	}{t.Kind, t.Speaker, t.Text, t.Tool, t.Start.Seconds(), t.End.Seconds()})
+}
+
+// Line renders the turn as a single line of plain text, e.g.
+// "[01:05] Customer: Hello"
+func (t Turn) Line() string {
+	prefix := "[" + FormatOffset(t.Start) + "] "
+	switch t.Kind {
+	case KindToolCall:
+		return prefix + "→ " + t.Text
+	case KindToolResult:
+		return prefix + "← " + t.Tool + ": " + t.Text
+	default:
+		return prefix + t.Speaker + ": " + t.Text
+	}
+}
+
+// FormatOffset formats an offset from the start of a call as mm:ss, or
+// h:mm:ss for calls of an hour or more
+func FormatOffset(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%02d:%02d", s/60, s%60)
+}
+
+// Parse turns a call's messages, as loosely typed JSON, into transcript
+// turns. A message listing several tool calls becomes one turn per call.
+func Parse(messages []interface{}) []Turn {
+	var turns []Turn
+	for _, m := range messages {
+		msg, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		role, _ := msg["role"].(string)
+		text, _ := msg["message"].(string)
+		start := seconds(msg["secondsFromStart"])
+		end := start
+		if ms, ok := msg["duration"].(float64); ok {
+			end = start + time.Duration(ms*float64(time.Millisecond))
+		} else if from, ok := msg["time"].(float64); ok {
+			if to, ok := msg["endTime"].(float64); ok && to > from {
+				end = start + time.Duration((to-from)*float64(time.Millisecond))
+			}
+		}
+
+		switch role {
+		case "user":
+			turns = append(turns, Turn{Kind: KindCustomer, Speaker: "Customer", Text: strings.TrimSpace(text), Start: start, End: end})
+		case "bot", "assistant":
+			turns = append(turns, Turn{Kind: KindAssistant, Speaker: "Assistant", Text: strings.TrimSpace(text), Start: start, End: end})
+		case "system":
+			turns = append(turns, Turn{Kind: KindSystem, Speaker: "System", Text: strings.TrimSpace(text), Start: start, End: end})
+		case "tool_calls":
+			calls, _ := msg["toolCalls"].([]interface{})
+			for _, c := range calls {
+				name, args := toolCall(c)
+				turns = append(turns, Turn{Kind: KindToolCall, Speaker: "Tool call", Text: name + "(" + args + ")", Tool: name, Start: start, End: end})
+			}
+		case "tool_call_result":
+			name, _ := msg["name"].(string)
+			result := msg["result"]
+			if result == nil {
+				result = msg["message"]
+			}
+			turns = append(turns, Turn{Kind: KindToolResult, Speaker: "Tool result", Text: compact(result), Tool: name, Start: start, End: end})
+		default:
+			if text != "" {
+				turns = append(turns, Turn{Kind: Kind(role), Speaker: role, Text: strings.TrimSpace(text), Start: start, End: end})
+			}
+		}
+	}
+	return turns
+}
+
+// toolCall returns the function name and arguments of an OpenAI-style tool
+// call
+func toolCall(c interface{}) (string, string) {
+	call, _ := c.(map[string]interface{})
+	fn, _ := call["function"].(map[string]interface{})
+	name, _ := fn["name"].(string)
+	if name == "" {
+		name, _ = call["name"].(string)
+	}
+	args := fn["arguments"]
+	if args == nil {
+		args = call["arguments"]
+	}
+	return name, compact(args)
+}
+
+// compact renders a value on one line. JSON strings are compacted as well,
+// since tool arguments and results often arrive encoded.
+func compact(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		var decoded interface{}
+		if json.Unmarshal([]byte(val), &decoded) == nil {
+			if _, isObj := decoded.(map[string]interface{}); isObj {
+				return compact(decoded)
+			}
+			if _, isList := decoded.([]interface{}); isList {
+				return compact(decoded)
+			}
+		}
+		return strings.Join(strings.Fields(val), " ")
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(b)
+	}
+}
+
+func seconds(v interface{}) time.Duration {
+	s, _ := v.(float64)
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,85 @@
+package transcript
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const messagesJSON = `[
+	{"role": "system", "message": "You are a helpful agent.", "time": 1700000000000, "secondsFromStart": 0},
+	{"role": "bot", "message": "Hi, how can I help?", "time": 1700000000500, "endTime": 1700000002000, "secondsFromStart": 0.5, "duration": 1500},
+	{"role": "user", "message": "  What's my   balance? ", "time": 1700000003000, "endTime": 1700000004500, "secondsFromStart": 3},
+	{"role": "tool_calls", "toolCalls": [{"id": "c1", "type": "function", "function": {"name": "getBalance", "arguments": "{\"account\": \"42\"}"}}], "time": 1700000005000, "secondsFromStart": 5},
+	{"role": "tool_call_result", "toolCallId": "c1", "name": "getBalance", "result": "{\"balance\": 10}", "time": 1700000005400, "secondsFromStart": 5.4},
+	{"role": "bot", "message": "You have ten dollars.", "time": 1700000066000, "secondsFromStart": 66}
+]`
+
+func parseFixture(t *testing.T) []Turn {
+	t.Helper()
+	var messages []interface{}
+	require.NoError(t, json.Unmarshal([]byte(messagesJSON), &messages))
+	return Parse(messages)
+}
+
+func TestParse(t *testing.T) {
+	turns := parseFixture(t)
+	require.Len(t, turns, 6)
+
+	assert.Equal(t, KindSystem, turns[0].Kind)
+	assert.Equal(t, Turn{Kind: KindAssistant, Speaker: "Assistant", Text: "Hi, how can I help?", Start: 500 * time.Millisecond, End: 2 * time.Second}, turns[1])
+	assert.Equal(t, "What's my   balance?", turns[2].Text)
+	assert.Equal(t, 4500*time.Millisecond, turns[2].End)
+	assert.Equal(t, Turn{Kind: KindToolCall, Speaker: "Tool call", Text: `getBalance({"account":"42"})`, Tool: "getBalance", Start: 5 * time.Second, End: 5 * time.Second}, turns[3])
+	assert.Equal(t, `{"balance":10}`, turns[4].Text)
+	assert.Equal(t, "getBalance", turns[4].Tool)
+
+	assert.Equal(t, "[00:03] Customer: What's my   balance?", turns[2].Line())
+	assert.Equal(t, `[00:05] → getBalance({"account":"42"})`, turns[3].Line())
+	assert.Equal(t, `[00:05] ← getBalance: {"balance":10}`, turns[4].Line())
+	assert.Equal(t, "[01:06] Assistant: You have ten dollars.", turns[5].Line())
+}
+
+func TestTurnJSON(t *testing.T) {
+	b, err := json.Marshal(parseFixture(t)[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind":"assistant","speaker":"Assistant","text":"Hi, how can I help?","start":0.5,"end":2}`, string(b))
+}
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "00:00", FormatOffset(-time.Second))
+	assert.Equal(t, "01:05", FormatOffset(65*time.Second+900*time.Millisecond))
+	assert.Equal(t, "1:00:01", FormatOffset(time.Hour+time.Second))
+}
+
+func TestRedactText(t *testing.T) {
+	cases := map[string]string{
+		"Call me at +14155550123 please":           "Call me at [phone] please",
+		"It's +1 (415) 555-0123.":                  "It's [phone].",
+		"Office: (415) 555-0123":                   "Office: [phone]",
+		"London 020 7183 8750":                     "London [phone]",
+		"try 415.555.0123 or 415-555-0123":         "try [phone] or [phone]",
+		"Mail ada.lovelace+vapi@example.co.uk now": "Mail [email] now",
+		"Card 4242 4242 4242 4242 exp 12/27":       "Card [card] exp 12/27",
+		"Card 4111-1111-1111-1111":                 "Card [card]",
+		"Order 12 at 3pm for $40":                  "Order 12 at 3pm for $40",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, RedactText(in), in)
+	}
+
+	// Digits of card length that fail the Luhn check are still hidden whole
+	assert.Equal(t, "Ref [number]", RedactText("Ref 4242 4242 4242 4241"))
+	assert.Equal(t, "card [number]", RedactText("card 4111 1111 1111 1112"))
+	assert.Equal(t, "Call [phone]", RedactText("Call +8613912345678901"))
+}
+
+func TestRedact(t *testing.T) {
+	turns := []Turn{{Kind: KindCustomer, Text: "my email is ada@example.com"}}
+	redacted := Redact(turns)
+	assert.Equal(t, "my email is [email]", redacted[0].Text)
+	assert.Equal(t, "my email is ada@example.com", turns[0].Text)
+}